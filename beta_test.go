@@ -0,0 +1,33 @@
+package hoglet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegularizedIncompleteBeta(t *testing.T) {
+	tests := []struct {
+		x, a, b float64
+		want    float64
+	}{
+		{x: 0, a: 2, b: 3, want: 0},
+		{x: 1, a: 2, b: 3, want: 1},
+		{x: 0.5, a: 1, b: 1, want: 0.5},              // uniform
+		{x: 0.3, a: 1, b: 1, want: 0.3},              // uniform
+		{x: 0.5, a: 5, b: 5, want: 0.5},              // symmetric
+		{x: 0.2, a: 2, b: 3, want: 0.1808},           // 1 - 0.8^4 - 4*0.2*0.8^3
+		{x: 0.9, a: 2, b: 1, want: 0.81},             // x^2
+		{x: 0.1, a: 51, b: 51, want: 1.1523e-24},     // far in the tail
+		{x: 0.6, a: 60.5, b: 40.5, want: 0.50272710}, // fractional parameters
+	}
+
+	for _, tt := range tests {
+		got := regularizedIncompleteBeta(tt.x, tt.a, tt.b)
+		if tt.want == 0 {
+			assert.Zero(t, got)
+			continue
+		}
+		assert.InEpsilon(t, tt.want, got, 1e-3, "I_%v(%v, %v)", tt.x, tt.a, tt.b)
+	}
+}
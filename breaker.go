@@ -3,29 +3,31 @@ package hoglet
 import (
 	"fmt"
 	"math"
+	"math/rand/v2"
 	"sync/atomic"
 	"time"
 )
 
-// stateChange encodes what the circuit should do after observing a call.
-type stateChange int
+// StateChange encodes what the circuit should do after observing a call. It is returned by a [Breaker] (see
+// [BreakerFunc]).
+type StateChange int
 
 const (
-	// stateChangeNone means the circuit should keep its current state.
-	stateChangeNone stateChange = iota
-	// stateChangeOpen means the circuit should open.
-	stateChangeOpen
-	// stateChangeClose means the circuit should close.
-	stateChangeClose
+	// StateChangeNone means the circuit should keep its current state.
+	StateChangeNone StateChange = iota
+	// StateChangeOpen means the circuit should open.
+	StateChangeOpen
+	// StateChangeClose means the circuit should close.
+	StateChangeClose
 )
 
-func (s stateChange) String() string {
+func (s StateChange) String() string {
 	switch s {
-	case stateChangeNone:
+	case StateChangeNone:
 		return "none"
-	case stateChangeOpen:
+	case StateChangeOpen:
 		return "open"
-	case stateChangeClose:
+	case StateChangeClose:
 		return "close"
 	default:
 		return "unknown"
@@ -55,15 +57,54 @@ func toStore(i float64) uint64 {
 	return math.Float64bits(i)
 }
 
+// addFloat atomically adds delta to the float64 held by a (see [toStore]) and returns the new value.
+func addFloat(a *atomic.Uint64, delta float64) float64 {
+	for {
+		old := a.Load()
+		sum := fromStore(old) + delta
+		if a.CompareAndSwap(old, toStore(sum)) {
+			return sum
+		}
+	}
+}
+
+// checkCloseThreshold checks the close threshold of a rate-based breaker against its open threshold (see
+// [EWMABreaker.WithCloseThreshold]).
+func checkCloseThreshold(closeThreshold, threshold float64) error {
+	if closeThreshold < 0 || closeThreshold > threshold {
+		return fmt.Errorf("close threshold (%v) must be between 0 and the threshold (%v)", closeThreshold, threshold)
+	}
+
+	return nil
+}
+
+// windowHalfOpenDelay defaults the half-open delay of a time-based breaker to its window size, after which the breaker
+// self-heals anyway, and rejects an explicit delay exceeding it.
+func windowHalfOpenDelay(o *options, size time.Duration) error {
+	switch {
+	case o.halfOpenDelay == 0:
+		o.halfOpenDelay = size
+	case o.halfOpenDelay > size:
+		// An explicit delay larger than the window would never let the circuit go half-open (the window expires and
+		// closes it first), so reject it instead of silently discarding the caller's value.
+		return fmt.Errorf("half-open delay (%s) cannot exceed window size (%s)", o.halfOpenDelay, size)
+	}
+
+	return nil
+}
+
 // EWMABreaker is a [Breaker] that uses an exponentially weighted moving failure rate. See [NewEWMABreaker] for details.
 //
 // A zero EWMABreaker is ready to use, but will never open.
 type EWMABreaker struct {
-	decay     float64
-	threshold float64
+	decay           float64
+	threshold       float64
+	closeThreshold  float64 // see [EWMABreaker.WithCloseThreshold]
+	minimumRequests int64   // see [WithMinimumRequests]
 
 	// State
-	failureRate atomic.Uint64
+	failureRate  atomic.Uint64
+	observations atomic.Int64 // only counted up to minimumRequests
 }
 
 // NewEWMABreaker creates a new [EWMABreaker] with the given sample count and threshold. It uses an Exponentially
@@ -87,8 +128,9 @@ func NewEWMABreaker(sampleCount uint, failureThreshold float64) *EWMABreaker {
 		// Span-based exponential smoothing factor: https://en.wikipedia.org/wiki/Exponential_smoothing
 		// decay = 2/(N+1) lies in (0,1] for every sampleCount >= 1 (and is exactly 1 at sampleCount == 1, i.e. only the
 		// newest sample counts). sampleCount == 0 yields decay = 2, which is rejected in apply.
-		decay:     2 / (float64(sampleCount) + 1),
-		threshold: failureThreshold,
+		decay:          2 / (float64(sampleCount) + 1),
+		threshold:      failureThreshold,
+		closeThreshold: failureThreshold,
 	}
 
 	e.failureRate.Store(toStore(math.SmallestNonzeroFloat64)) // start closed; also work around "initial value" problem
@@ -96,35 +138,80 @@ func NewEWMABreaker(sampleCount uint, failureThreshold float64) *EWMABreaker {
 	return e
 }
 
-func (e *EWMABreaker) observe(halfOpen, failure bool) stateChange {
+// WithCloseThreshold sets a separate failure rate at or below which the breaker closes the circuit again and returns
+// the breaker. Without it, the breaker closes as soon as its failure rate is at or below the threshold it opens at,
+// which may cause the circuit to flap when the rate hovers around it.
+// With a lower close threshold, the breaker opens above its threshold, closes at or below the close threshold, and
+// keeps the circuit's current state in between (hysteresis). Successful half-open probes still close the circuit.
+//
+// The close threshold must be between 0 and the breaker's threshold, otherwise [NewCircuit] returns an error. Being a
+// setting of the breaker, breakers combined via [AnyOf] or [AllOf] may use different close thresholds.
+// It must be set before passing the breaker to [NewCircuit], e.g. NewEWMABreaker(10, 0.1).WithCloseThreshold(0.05).
+func (e *EWMABreaker) WithCloseThreshold(threshold float64) *EWMABreaker {
+	e.closeThreshold = threshold
+	return e
+}
+
+func (e *EWMABreaker) observe(halfOpen, failure bool) StateChange {
+	return e.observeCall(halfOpen, failure, 1, 0)
+}
+
+func (e *EWMABreaker) measuresDuration() bool {
+	return false
+}
+
+// observeCall implements callBreaker. A failure with weight w counts like w consecutive failures.
+func (e *EWMABreaker) observeCall(halfOpen, failure bool, weight float64, _ time.Duration) StateChange {
 	if e.threshold == 0 {
-		return stateChangeNone
+		return StateChangeNone
 	}
 
 	if !failure && halfOpen {
 		e.failureRate.Store(toStore(e.threshold))
-		return stateChangeClose
+		return StateChangeClose
 	}
 
 	var value = 0.0
+	decay := e.decay
 	if failure {
 		value = 1.0
+		if weight != 1 {
+			// Applying the decay w times: the previous rate keeps (1-decay)^w of its weight.
+			decay = 1 - math.Pow(1-e.decay, weight)
+		}
+	}
+
+	// Without a previous rate, the first observation sets it, with a failure weighing less than 1 only counting partially.
+	initial := value
+	if failure {
+		initial = min(1, weight)
 	}
 
 	// Unconditionally setting via swap and maybe overwriting is faster in the initial case.
-	failureRate := fromStore(e.failureRate.Swap(toStore(value)))
+	failureRate := fromStore(e.failureRate.Swap(toStore(initial)))
 	if failureRate == math.SmallestNonzeroFloat64 {
-		failureRate = value
+		failureRate = initial
 	} else {
-		failureRate = (value * e.decay) + (failureRate * (1 - e.decay))
+		failureRate = (value * decay) + (failureRate * (1 - decay))
 		e.failureRate.Store(toStore(failureRate))
 	}
 
+	// Counting is skipped once the minimum is reached, keeping the hot path free of further writes.
+	observations := e.observations.Load()
+	if observations < e.minimumRequests {
+		observations = e.observations.Add(1)
+	}
+
 	if failureRate > e.threshold {
-		return stateChangeOpen
-	} else {
-		return stateChangeClose
+		if !halfOpen && observations < e.minimumRequests {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else if failureRate <= e.closeThreshold {
+		return StateChangeClose
 	}
+
+	return StateChangeNone
 }
 
 // apply implements Option.
@@ -143,21 +230,155 @@ func (e *EWMABreaker) apply(o *options) error {
 		return fmt.Errorf("EWMABreaker requires a sample count of at least 1")
 	}
 
+	if err := checkCloseThreshold(e.closeThreshold, e.threshold); err != nil {
+		return fmt.Errorf("EWMABreaker %w", err)
+	}
+
+	e.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// HalfLifeEWMABreaker is a [Breaker] that uses an exponentially weighted moving failure rate decaying over time. See
+// [NewHalfLifeEWMABreaker] for details.
+//
+// A zero HalfLifeEWMABreaker is rejected by [NewCircuit].
+type HalfLifeEWMABreaker struct {
+	halfLife        time.Duration
+	threshold       float64
+	closeThreshold  float64 // see [EWMABreaker.WithCloseThreshold]
+	minimumRequests int64   // see [WithMinimumRequests]
+
+	// State
+
+	failureRate  atomic.Uint64
+	lastObserved atomic.Int64 // monotonic nanoseconds since start (see nowNanos); 0 = no observation yet
+	observations atomic.Int64 // only counted up to minimumRequests
+}
+
+// NewHalfLifeEWMABreaker creates a new [HalfLifeEWMABreaker] with the given half-life and threshold. Like the
+// [EWMABreaker], it uses an Exponentially Weighted Moving Average to calculate the current failure rate, but previous
+// observations decay with the time elapsed instead of the number of observations made since.
+//
+// ⚠️ This is an observation-based breaker, which means it requires new calls to be able to update the failure rate, and
+// therefore REQUIRES the circuit to set a half-open threshold via [WithHalfOpenDelay]. Otherwise an open circuit will
+// never observe any successes and thus never close.
+//
+// Compared to the [EWMABreaker], its behavior does not depend on the throughput: an old failure weighs the same after
+// a given time, no matter how many calls have been made in between. This suits circuits with strongly varying
+// throughput (e.g. between day and night).
+//
+// The halfLife is the time after which an observation has lost half of its weight. It must be positive.
+//
+// The failureThreshold is the failure rate above which the breaker should open (0.0-1.0).
+func NewHalfLifeEWMABreaker(halfLife time.Duration, failureThreshold float64) *HalfLifeEWMABreaker {
+	return &HalfLifeEWMABreaker{
+		halfLife:       halfLife,
+		threshold:      failureThreshold,
+		closeThreshold: failureThreshold,
+	}
+}
+
+// WithCloseThreshold sets a separate failure rate at or below which the breaker closes the circuit again and returns
+// the breaker. See [EWMABreaker.WithCloseThreshold] for details.
+func (h *HalfLifeEWMABreaker) WithCloseThreshold(threshold float64) *HalfLifeEWMABreaker {
+	h.closeThreshold = threshold
+	return h
+}
+
+func (h *HalfLifeEWMABreaker) observe(halfOpen, failure bool) StateChange {
+	if !failure && halfOpen {
+		h.failureRate.Store(toStore(h.threshold))
+		return StateChangeClose
+	}
+
+	var value = 0.0
+	if failure {
+		value = 1.0
+	}
+
+	var failureRate float64
+
+	now := nowNanos()
+	if lastObserved := h.lastObserved.Swap(now); lastObserved == 0 {
+		// the first observation seeds the failure rate
+		failureRate = value
+		h.failureRate.Store(toStore(failureRate))
+	} else {
+		// The weight of the new value depends on the time elapsed since the previous observation: after one half-life,
+		// the previous failure rate only counts half. Concurrent observations may see a (near) zero elapsed time, which
+		// is fine since they happened at (nearly) the same time.
+		elapsed := max(0, float64(now-lastObserved))
+		decay := 1 - math.Exp2(-elapsed/float64(h.halfLife))
+
+		for {
+			old := h.failureRate.Load()
+			failureRate = (value * decay) + (fromStore(old) * (1 - decay))
+			if h.failureRate.CompareAndSwap(old, toStore(failureRate)) {
+				break
+			}
+		}
+	}
+
+	observations := h.observations.Load()
+	if observations < h.minimumRequests {
+		observations = h.observations.Add(1)
+	}
+
+	if failureRate > h.threshold {
+		if !halfOpen && observations < h.minimumRequests {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else if failureRate <= h.closeThreshold {
+		return StateChangeClose
+	}
+
+	return StateChangeNone
+}
+
+// apply implements Option.
+func (h *HalfLifeEWMABreaker) apply(o *options) error {
+	if o.halfOpenDelay == 0 {
+		return fmt.Errorf("HalfLifeEWMABreaker requires a half-open delay")
+	}
+
+	if h.threshold < 0 || h.threshold > 1 {
+		return fmt.Errorf("HalfLifeEWMABreaker threshold must be between 0 and 1")
+	}
+
+	if h.halfLife <= 0 {
+		return fmt.Errorf("HalfLifeEWMABreaker half-life must be positive")
+	}
+
+	if err := checkCloseThreshold(h.closeThreshold, h.threshold); err != nil {
+		return fmt.Errorf("HalfLifeEWMABreaker %w", err)
+	}
+
+	h.minimumRequests = o.minimumRequests
+
 	return nil
 }
 
 // SlidingWindowBreaker is a [Breaker] that uses a sliding window to determine the error rate.
 type SlidingWindowBreaker struct {
-	windowSize time.Duration
-	threshold  float64
+	windowSize      time.Duration
+	threshold       float64
+	closeThreshold  float64 // see [EWMABreaker.WithCloseThreshold]
+	minimumRequests int64   // see [WithMinimumRequests]
 
 	// State
 
 	currentStart        atomic.Int64 // monotonic nanoseconds since start (see nowNanos)
 	currentSuccessCount atomic.Int64
-	currentFailureCount atomic.Int64
+	currentFailureCount atomic.Uint64 // weighted (see [WithFailureWeight]); float64 bits
 	lastSuccessCount    atomic.Int64
-	lastFailureCount    atomic.Int64
+	lastFailureCount    atomic.Uint64 // weighted (see [WithFailureWeight]); float64 bits
+
+	// currentFailureCalls and lastFailureCalls count failed calls unweighted, for the minimum number of calls. They are
+	// only counted if minimumRequests is set.
+	currentFailureCalls atomic.Int64
+	lastFailureCalls    atomic.Int64
 }
 
 // NewSlidingWindowBreaker creates a new [SlidingWindowBreaker] with the given window size and failure rate threshold.
@@ -175,25 +396,46 @@ type SlidingWindowBreaker struct {
 // The failureThreshold is the failure rate above which the breaker should open (0.0-1.0).
 func NewSlidingWindowBreaker(windowSize time.Duration, failureThreshold float64) *SlidingWindowBreaker {
 	s := &SlidingWindowBreaker{
-		windowSize: windowSize,
-		threshold:  failureThreshold,
+		windowSize:     windowSize,
+		threshold:      failureThreshold,
+		closeThreshold: failureThreshold,
 	}
 
 	return s
 }
 
-func (s *SlidingWindowBreaker) observe(halfOpen, failure bool) stateChange {
+// WithCloseThreshold sets a separate failure rate at or below which the breaker closes the circuit again and returns
+// the breaker. See [EWMABreaker.WithCloseThreshold] for details.
+func (s *SlidingWindowBreaker) WithCloseThreshold(threshold float64) *SlidingWindowBreaker {
+	s.closeThreshold = threshold
+	return s
+}
+
+func (s *SlidingWindowBreaker) observe(halfOpen, failure bool) StateChange {
+	return s.observeCall(halfOpen, failure, 1, 0)
+}
+
+func (s *SlidingWindowBreaker) measuresDuration() bool {
+	return false
+}
+
+// observeCall implements callBreaker. A failure with weight w counts like w failures.
+func (s *SlidingWindowBreaker) observeCall(halfOpen, failure bool, weight float64, _ time.Duration) StateChange {
 	var (
-		lastFailureCount    int64
+		lastFailureCount    float64
 		lastSuccessCount    int64
-		currentFailureCount int64
+		lastFailureCalls    int64
+		currentFailureCount float64
 		currentSuccessCount int64
+		currentFailureCalls int64
 	)
 
 	if !failure && halfOpen {
-		return stateChangeClose
+		return StateChangeClose
 	}
 
+	countCalls := s.minimumRequests > 0
+
 	currentStartNanos := s.currentStart.Load()
 	sinceStart := sinceNanos(currentStartNanos)
 
@@ -202,34 +444,53 @@ func (s *SlidingWindowBreaker) observe(halfOpen, failure bool) stateChange {
 	// some near zero value.
 	if (currentStartNanos == 0 || sinceStart > s.windowSize) && s.currentStart.CompareAndSwap(currentStartNanos, nowNanos()) {
 		sinceStart = 0
-		lastFailureCount = s.lastFailureCount.Swap(s.currentFailureCount.Swap(0))
+		lastFailureCount = fromStore(s.lastFailureCount.Swap(s.currentFailureCount.Swap(toStore(0))))
 		lastSuccessCount = s.lastSuccessCount.Swap(s.currentSuccessCount.Swap(0))
+		if countCalls {
+			lastFailureCalls = s.lastFailureCalls.Swap(s.currentFailureCalls.Swap(0))
+		}
 	} else {
-		lastFailureCount = s.lastFailureCount.Load()
+		lastFailureCount = fromStore(s.lastFailureCount.Load())
 		lastSuccessCount = s.lastSuccessCount.Load()
+		if countCalls {
+			lastFailureCalls = s.lastFailureCalls.Load()
+		}
 	}
 
 	if failure {
-		currentFailureCount = s.currentFailureCount.Add(1)
+		currentFailureCount = addFloat(&s.currentFailureCount, weight)
 		currentSuccessCount = s.currentSuccessCount.Load()
+		if countCalls {
+			currentFailureCalls = s.currentFailureCalls.Add(1)
+		}
 	} else {
 		currentSuccessCount = s.currentSuccessCount.Add(1)
-		currentFailureCount = s.currentFailureCount.Load()
+		currentFailureCount = fromStore(s.currentFailureCount.Load())
+		if countCalls {
+			currentFailureCalls = s.currentFailureCalls.Load()
+		}
 	}
 
 	// We use the last window's weight to determine how much the last window's failure rate should count.
 	// It is the remaining portion of the last window still "visible" in the current window.
-	lastWindowWeight := max(0, s.windowSize.Seconds()-sinceStart.Seconds()) / s.windowSize.Seconds()
+	lastWindowWeight := lastWindowShare(s.windowSize, sinceStart)
 
-	weightedFailures := float64(lastFailureCount)*lastWindowWeight + float64(currentFailureCount)
-	weightedTotal := float64(lastFailureCount+lastSuccessCount)*lastWindowWeight + float64(currentFailureCount+currentSuccessCount)
+	weightedFailures := lastFailureCount*lastWindowWeight + currentFailureCount
+	weightedTotal := (lastFailureCount+float64(lastSuccessCount))*lastWindowWeight + currentFailureCount + float64(currentSuccessCount)
 	failureRate := weightedFailures / weightedTotal
 
 	if failureRate > s.threshold {
-		return stateChangeOpen
-	} else {
-		return stateChangeClose
+		// The minimum counts calls, so weighted failures must not make up for missing ones.
+		calls := float64(lastFailureCalls+lastSuccessCount)*lastWindowWeight + float64(currentFailureCalls+currentSuccessCount)
+		if !halfOpen && calls < float64(s.minimumRequests) {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else if failureRate <= s.closeThreshold {
+		return StateChangeClose
 	}
+
+	return StateChangeNone
 }
 
 // apply implements Option.
@@ -238,15 +499,1025 @@ func (s *SlidingWindowBreaker) apply(o *options) error {
 		return fmt.Errorf("SlidingWindowBreaker threshold must be between 0 and 1")
 	}
 
-	switch {
-	case o.halfOpenDelay == 0:
-		// Unset: default to the window size, after which the breaker self-heals anyway.
-		o.halfOpenDelay = s.windowSize
-	case o.halfOpenDelay > s.windowSize:
-		// An explicit delay larger than the window would never let the circuit go half-open (the window expires and
-		// closes it first), so reject it instead of silently discarding the caller's value.
-		return fmt.Errorf("SlidingWindowBreaker half-open delay (%s) cannot exceed window size (%s)", o.halfOpenDelay, s.windowSize)
+	if err := windowHalfOpenDelay(o, s.windowSize); err != nil {
+		return fmt.Errorf("SlidingWindowBreaker %w", err)
+	}
+
+	if err := checkCloseThreshold(s.closeThreshold, s.threshold); err != nil {
+		return fmt.Errorf("SlidingWindowBreaker %w", err)
+	}
+
+	s.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// ConsecutiveFailuresBreaker is a [Breaker] that opens after a number of consecutive failures. See
+// [NewConsecutiveFailuresBreaker] for details.
+//
+// A zero ConsecutiveFailuresBreaker is rejected by [NewCircuit].
+type ConsecutiveFailuresBreaker struct {
+	threshold       int64
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	streak       atomic.Int64 // number of failures observed since the last success
+	observations atomic.Int64 // only counted up to minimumRequests
+}
+
+// NewConsecutiveFailuresBreaker creates a new [ConsecutiveFailuresBreaker] that opens once the given number of
+// consecutive failures has been observed. Any success resets the streak.
+//
+// ⚠️ This is an observation-based breaker, which means it requires new calls to be able to close again, and therefore
+// REQUIRES the circuit to set a half-open threshold via [WithHalfOpenDelay]. Otherwise an open circuit will never
+// observe any successes and thus never close.
+//
+// Unlike the rate-based breakers, it does not depend on throughput, which makes it suitable for low-traffic circuits
+// where a meaningful failure rate never builds up.
+//
+// The failure count must be at least 1. A value of 1 opens the circuit on every failure. A failure count of 0 is
+// rejected by [NewCircuit].
+func NewConsecutiveFailuresBreaker(n uint) *ConsecutiveFailuresBreaker {
+	return &ConsecutiveFailuresBreaker{
+		threshold: int64(n),
+	}
+}
+
+func (c *ConsecutiveFailuresBreaker) observe(halfOpen, failure bool) StateChange {
+	if c.threshold == 0 {
+		return StateChangeNone
+	}
+
+	observations := c.observations.Load()
+	if observations < c.minimumRequests {
+		observations = c.observations.Add(1)
 	}
 
+	if !failure {
+		c.streak.Store(0)
+		return StateChangeClose
+	}
+
+	// A failed probe keeps the circuit open, regardless of the streak or minimum.
+	if halfOpen || (c.streak.Add(1) >= c.threshold && observations >= c.minimumRequests) {
+		return StateChangeOpen
+	}
+
+	return StateChangeNone
+}
+
+// apply implements Option.
+func (c *ConsecutiveFailuresBreaker) apply(o *options) error {
+	if o.halfOpenDelay == 0 {
+		return fmt.Errorf("ConsecutiveFailuresBreaker requires a half-open delay")
+	}
+
+	if c.threshold < 1 {
+		return fmt.Errorf("ConsecutiveFailuresBreaker requires a failure count of at least 1")
+	}
+
+	c.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// CountWindowBreaker is a [Breaker] that uses the exact failure rate over the last N observed calls. See
+// [NewCountWindowBreaker] for details.
+//
+// A zero CountWindowBreaker is rejected by [NewCircuit].
+type CountWindowBreaker struct {
+	threshold       float64
+	closeThreshold  float64 // see [EWMABreaker.WithCloseThreshold]
+	minimumRequests int64   // see [WithMinimumRequests]
+
+	// State
+
+	outcomes     []atomic.Bool // ring buffer of the last N outcomes; true = failure
+	next         atomic.Uint64 // total number of observations; the next slot is next % len(outcomes)
+	failureCount atomic.Int64  // number of failures currently in the ring buffer
+}
+
+// NewCountWindowBreaker creates a new [CountWindowBreaker] with the given window size and failure rate threshold.
+//
+// ⚠️ This is an observation-based breaker, which means it requires new calls to be able to update the failure rate, and
+// therefore REQUIRES the circuit to set a half-open threshold via [WithHalfOpenDelay]. Otherwise an open circuit will
+// never observe any successes and thus never close.
+//
+// Compared to the [SlidingWindowBreaker], the window is defined by a number of calls instead of a time interval, which
+// makes its behavior independent of the throughput. Until the window is filled, the failure rate is calculated over
+// the calls observed so far.
+//
+// The windowSize is the number of calls over which to calculate the failure rate. It must be at least 1; a window size
+// of 0 is rejected by [NewCircuit].
+//
+// The failureThreshold is the failure rate above which the breaker should open (0.0-1.0).
+func NewCountWindowBreaker(windowSize uint, failureThreshold float64) *CountWindowBreaker {
+	return &CountWindowBreaker{
+		threshold:      failureThreshold,
+		closeThreshold: failureThreshold,
+		outcomes:       make([]atomic.Bool, windowSize),
+	}
+}
+
+// WithCloseThreshold sets a separate failure rate at or below which the breaker closes the circuit again and returns
+// the breaker. See [EWMABreaker.WithCloseThreshold] for details.
+func (c *CountWindowBreaker) WithCloseThreshold(threshold float64) *CountWindowBreaker {
+	c.closeThreshold = threshold
+	return c
+}
+
+func (c *CountWindowBreaker) observe(halfOpen, failure bool) StateChange {
+	if len(c.outcomes) == 0 {
+		return StateChangeNone
+	}
+
+	if !failure && halfOpen {
+		return StateChangeClose
+	}
+
+	n := c.next.Add(1)
+	slot := (n - 1) % uint64(len(c.outcomes))
+
+	// Swapping the slot tells us which outcome dropped out of the window. Concurrent observations may briefly skew
+	// the failure count, but it never drifts since every swap is accounted for exactly once.
+	var failureCount int64
+	switch evicted := c.outcomes[slot].Swap(failure); {
+	case failure && !evicted:
+		failureCount = c.failureCount.Add(1)
+	case !failure && evicted:
+		failureCount = c.failureCount.Add(-1)
+	default:
+		failureCount = c.failureCount.Load()
+	}
+
+	total := min(n, uint64(len(c.outcomes)))
+	failureRate := float64(failureCount) / float64(total)
+
+	if failureRate > c.threshold {
+		if !halfOpen && int64(total) < c.minimumRequests {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else if failureRate <= c.closeThreshold {
+		return StateChangeClose
+	}
+
+	return StateChangeNone
+}
+
+// apply implements Option.
+func (c *CountWindowBreaker) apply(o *options) error {
+	if o.halfOpenDelay == 0 {
+		return fmt.Errorf("CountWindowBreaker requires a half-open delay")
+	}
+
+	if c.threshold < 0 || c.threshold > 1 {
+		return fmt.Errorf("CountWindowBreaker threshold must be between 0 and 1")
+	}
+
+	if len(c.outcomes) == 0 {
+		return fmt.Errorf("CountWindowBreaker requires a window size of at least 1")
+	}
+
+	if o.minimumRequests > int64(len(c.outcomes)) {
+		return fmt.Errorf("CountWindowBreaker minimum requests (%d) cannot exceed window size (%d)", o.minimumRequests, len(c.outcomes))
+	}
+	if err := checkCloseThreshold(c.closeThreshold, c.threshold); err != nil {
+		return fmt.Errorf("CountWindowBreaker %w", err)
+	}
+
+	c.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// BucketedSlidingWindowBreaker is a [Breaker] that uses a sliding window divided into buckets to determine the error
+// rate. See [NewBucketedSlidingWindowBreaker] for details.
+//
+// A zero BucketedSlidingWindowBreaker is rejected by [NewCircuit].
+type BucketedSlidingWindowBreaker struct {
+	windowSize      time.Duration
+	bucketWidth     int64 // nanoseconds
+	threshold       float64
+	closeThreshold  float64 // see [EWMABreaker.WithCloseThreshold]
+	minimumRequests int64   // see [WithMinimumRequests]
+
+	// State
+
+	buckets []windowBucket
+}
+
+// windowBucket holds the observations of a single sub-window of a [BucketedSlidingWindowBreaker].
+type windowBucket struct {
+	epoch        atomic.Int64 // index of the sub-window (monotonic nanoseconds / bucket width) this bucket counts for
+	successCount atomic.Int64
+	failureCount atomic.Int64
+}
+
+// NewBucketedSlidingWindowBreaker creates a new [BucketedSlidingWindowBreaker] with the given window size, number of
+// buckets and failure rate threshold.
+//
+// Compared to the [SlidingWindowBreaker], which only keeps the current and the last window and weights the latter
+// linearly, this breaker divides the window into a fixed number of buckets and sums them up exactly. This avoids
+// over-weighting bursts at the end of the last window, at the cost of a slightly higher memory footprint and a
+// resolution of windowSize/buckets.
+//
+// Like the [SlidingWindowBreaker], this is a time-based breaker: the half-open delay (see [WithHalfOpenDelay]) defaults
+// to windowSize when unset and may not exceed it.
+//
+// The windowSize is the time interval over which to calculate the failure rate.
+//
+// The buckets is the number of sub-windows the window is divided into (e.g. 10). It must be at least 1.
+//
+// The failureThreshold is the failure rate above which the breaker should open (0.0-1.0).
+func NewBucketedSlidingWindowBreaker(windowSize time.Duration, buckets uint, failureThreshold float64) *BucketedSlidingWindowBreaker {
+	b := &BucketedSlidingWindowBreaker{
+		windowSize:     windowSize,
+		threshold:      failureThreshold,
+		closeThreshold: failureThreshold,
+		buckets:        make([]windowBucket, buckets),
+	}
+
+	if buckets > 0 {
+		b.bucketWidth = int64(windowSize) / int64(buckets)
+	}
+
+	return b
+}
+
+// WithCloseThreshold sets a separate failure rate at or below which the breaker closes the circuit again and returns
+// the breaker. See [EWMABreaker.WithCloseThreshold] for details.
+func (b *BucketedSlidingWindowBreaker) WithCloseThreshold(threshold float64) *BucketedSlidingWindowBreaker {
+	b.closeThreshold = threshold
+	return b
+}
+
+func (b *BucketedSlidingWindowBreaker) observe(halfOpen, failure bool) StateChange {
+	if !failure && halfOpen {
+		return StateChangeClose
+	}
+
+	epoch := nowNanos() / b.bucketWidth
+	// nowNanos may be negative under a fake clock (e.g. testing/synctest), so normalize the slot index.
+	slot := (epoch%int64(len(b.buckets)) + int64(len(b.buckets))) % int64(len(b.buckets))
+	bucket := &b.buckets[slot]
+
+	// Rotate the bucket if it still holds the counts of a sub-window that has passed. As in the
+	// [SlidingWindowBreaker], the CompareAndSwap ensures only one goroutine resets the bucket. Observations racing the
+	// reset may get lost, which we accept in favor of not locking.
+	if bucketEpoch := bucket.epoch.Load(); bucketEpoch != epoch && bucket.epoch.CompareAndSwap(bucketEpoch, epoch) {
+		bucket.successCount.Store(0)
+		bucket.failureCount.Store(0)
+	}
+
+	if failure {
+		bucket.failureCount.Add(1)
+	} else {
+		bucket.successCount.Add(1)
+	}
+
+	var failureCount, totalCount int64
+	for i := range b.buckets {
+		// Only sum up buckets that are still within the window. Stale buckets are reset lazily when rotated.
+		if epoch-b.buckets[i].epoch.Load() >= int64(len(b.buckets)) {
+			continue
+		}
+		failures := b.buckets[i].failureCount.Load()
+		failureCount += failures
+		totalCount += failures + b.buckets[i].successCount.Load()
+	}
+
+	failureRate := float64(failureCount) / float64(max(totalCount, 1))
+
+	if failureRate > b.threshold {
+		if !halfOpen && totalCount < b.minimumRequests {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else if failureRate <= b.closeThreshold {
+		return StateChangeClose
+	}
+
+	return StateChangeNone
+}
+
+// apply implements Option.
+func (b *BucketedSlidingWindowBreaker) apply(o *options) error {
+	if b.threshold < 0 || b.threshold > 1 {
+		return fmt.Errorf("BucketedSlidingWindowBreaker threshold must be between 0 and 1")
+	}
+
+	if len(b.buckets) == 0 {
+		return fmt.Errorf("BucketedSlidingWindowBreaker requires at least 1 bucket")
+	}
+
+	if b.bucketWidth <= 0 {
+		return fmt.Errorf("BucketedSlidingWindowBreaker window size (%s) is too small for %d buckets", b.windowSize, len(b.buckets))
+	}
+
+	if err := windowHalfOpenDelay(o, b.windowSize); err != nil {
+		return fmt.Errorf("BucketedSlidingWindowBreaker %w", err)
+	}
+
+	if err := checkCloseThreshold(b.closeThreshold, b.threshold); err != nil {
+		return fmt.Errorf("BucketedSlidingWindowBreaker %w", err)
+	}
+
+	b.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// SlowCallBreaker is a [Breaker] that opens when too many calls are slow. See [NewSlowCallBreaker] for details.
+//
+// A zero SlowCallBreaker is rejected by [NewCircuit].
+type SlowCallBreaker struct {
+	slowCallDuration time.Duration
+
+	// State
+
+	window *SlidingWindowBreaker // tracks slow calls as failures
+}
+
+// NewSlowCallBreaker creates a new [SlowCallBreaker] with the given window size, slow call duration and slow call
+// rate threshold.
+//
+// A call is considered slow if it takes longer than slowCallDuration. The duration is measured by the circuit, from
+// the moment the call is admitted until its result is observed (i.e. including the time until a context cancellation,
+// but excluding any time spent waiting in a [ConcurrencyLimiter]). Whether a call failed is not taken into account:
+// combine it with an error-based breaker to trip on both.
+//
+// The slow call rate is tracked the same way as the failure rate of a [SlidingWindowBreaker], which means this is a
+// time-based breaker with the same constraints on the half-open delay (see [NewSlidingWindowBreaker]). A fast
+// half-open probe closes the circuit.
+//
+// The windowSize is the time interval over which to calculate the slow call rate.
+//
+// The slowCallThreshold is the slow call rate above which the breaker should open (0.0-1.0).
+func NewSlowCallBreaker(windowSize, slowCallDuration time.Duration, slowCallThreshold float64) *SlowCallBreaker {
+	return &SlowCallBreaker{
+		slowCallDuration: slowCallDuration,
+		window:           NewSlidingWindowBreaker(windowSize, slowCallThreshold),
+	}
+}
+
+// WithCloseThreshold sets a separate slow call rate at or below which the breaker closes the circuit again and returns
+// the breaker. See [EWMABreaker.WithCloseThreshold] for details.
+func (s *SlowCallBreaker) WithCloseThreshold(threshold float64) *SlowCallBreaker {
+	if s.window != nil {
+		s.window.closeThreshold = threshold
+	}
+	return s
+}
+
+// observe implements Breaker. Without a duration, no call is considered slow.
+func (s *SlowCallBreaker) observe(halfOpen, failure bool) StateChange {
+	return s.observeCall(halfOpen, failure, 1, 0)
+}
+
+func (s *SlowCallBreaker) observeCall(halfOpen, _ bool, _ float64, duration time.Duration) StateChange {
+	return s.window.observe(halfOpen, duration > s.slowCallDuration)
+}
+
+func (s *SlowCallBreaker) measuresDuration() bool {
+	return true
+}
+
+// apply implements Option.
+func (s *SlowCallBreaker) apply(o *options) error {
+	if s.window == nil {
+		return fmt.Errorf("SlowCallBreaker must be created with NewSlowCallBreaker")
+	}
+
+	if s.slowCallDuration <= 0 {
+		return fmt.Errorf("SlowCallBreaker slow call duration must be positive")
+	}
+
+	if err := s.window.apply(o); err != nil {
+		return fmt.Errorf("SlowCallBreaker: %w", err)
+	}
+
+	return nil
+}
+
+// LatencyPercentileBreaker is a [Breaker] that opens when a latency percentile exceeds a limit. See
+// [NewLatencyPercentileBreaker] for details.
+//
+// A zero LatencyPercentileBreaker is rejected by [NewCircuit].
+type LatencyPercentileBreaker struct {
+	windowSize      time.Duration
+	percentile      float64
+	limit           time.Duration
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	currentStart atomic.Int64 // monotonic nanoseconds since start (see nowNanos)
+	current      atomic.Pointer[latencySketch]
+	last         atomic.Pointer[latencySketch]
+}
+
+// NewLatencyPercentileBreaker creates a new [LatencyPercentileBreaker] with the given window size, percentile and
+// latency limit.
+//
+// Call durations are tracked in a streaming quantile sketch with a relative accuracy of 2%, which is cheap enough to
+// update on every call. Like the [SlidingWindowBreaker], it keeps a current and a last window and weights the latter by
+// the portion still visible in the current window. The duration is measured the same way as for the
+// [SlowCallBreaker], and failures are not taken into account.
+//
+// This is a time-based breaker with the same constraints on the half-open delay as the [SlidingWindowBreaker] (see
+// [NewSlidingWindowBreaker]). A half-open probe within the limit closes the circuit.
+//
+// The windowSize is the time interval over which to estimate the percentile.
+//
+// The percentile is the quantile to estimate (e.g. 0.99 for p99), between 0 and 1 (exclusive).
+//
+// The limit is the latency above which the breaker should open.
+func NewLatencyPercentileBreaker(windowSize time.Duration, percentile float64, limit time.Duration) *LatencyPercentileBreaker {
+	l := &LatencyPercentileBreaker{
+		windowSize: windowSize,
+		percentile: percentile,
+		limit:      limit,
+	}
+
+	l.current.Store(newLatencySketch())
+	l.last.Store(newLatencySketch())
+
+	return l
+}
+
+// Percentile returns the current estimate of the configured latency percentile. It is meant for debugging purposes.
+func (l *LatencyPercentileBreaker) Percentile() time.Duration {
+	current, last, lastWindowWeight := l.sketches()
+	return current.weightedQuantile(l.percentile, last, lastWindowWeight)
+}
+
+// sketches returns the current and the last window's sketch, as well as the weight of the latter.
+func (l *LatencyPercentileBreaker) sketches() (current, last *latencySketch, lastWindowWeight float64) {
+	sinceStart := sinceNanos(l.currentStart.Load())
+	lastWindowWeight = lastWindowShare(l.windowSize, sinceStart)
+	return l.current.Load(), l.last.Load(), lastWindowWeight
+}
+
+// observe implements Breaker. Without a duration, every call is considered to be within the limit.
+func (l *LatencyPercentileBreaker) observe(halfOpen, failure bool) StateChange {
+	return l.observeCall(halfOpen, failure, 1, 0)
+}
+
+func (l *LatencyPercentileBreaker) measuresDuration() bool {
+	return true
+}
+
+func (l *LatencyPercentileBreaker) observeCall(halfOpen, _ bool, _ float64, duration time.Duration) StateChange {
+	if halfOpen {
+		if duration > l.limit {
+			return StateChangeOpen
+		}
+		return StateChangeClose
+	}
+
+	// Rotate the windows like the [SlidingWindowBreaker] does. The stale sketch is recycled as the new current one.
+	currentStartNanos := l.currentStart.Load()
+	if (currentStartNanos == 0 || sinceNanos(currentStartNanos) > l.windowSize) && l.currentStart.CompareAndSwap(currentStartNanos, nowNanos()) {
+		stale := l.last.Load()
+		stale.reset()
+		l.last.Store(l.current.Load())
+		l.current.Store(stale)
+	}
+
+	current, last, lastWindowWeight := l.sketches()
+	current.add(duration)
+
+	if total := float64(current.count.Load()) + float64(last.count.Load())*lastWindowWeight; total < float64(l.minimumRequests) {
+		return StateChangeNone
+	}
+
+	if current.weightedQuantile(l.percentile, last, lastWindowWeight) > l.limit {
+		return StateChangeOpen
+	} else {
+		return StateChangeClose
+	}
+}
+
+// apply implements Option.
+func (l *LatencyPercentileBreaker) apply(o *options) error {
+	if l.current.Load() == nil {
+		return fmt.Errorf("LatencyPercentileBreaker must be created with NewLatencyPercentileBreaker")
+	}
+
+	if l.windowSize <= 0 {
+		return fmt.Errorf("LatencyPercentileBreaker window size must be positive")
+	}
+
+	if l.percentile <= 0 || l.percentile >= 1 {
+		return fmt.Errorf("LatencyPercentileBreaker percentile must be between 0 and 1 (exclusive)")
+	}
+
+	if l.limit <= 0 {
+		return fmt.Errorf("LatencyPercentileBreaker limit must be positive")
+	}
+
+	if err := windowHalfOpenDelay(o, l.windowSize); err != nil {
+		return fmt.Errorf("LatencyPercentileBreaker %w", err)
+	}
+
+	l.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// AdaptiveThrottlingBreaker is a [Breaker] that rejects a proportional amount of calls instead of opening the circuit.
+// See [NewAdaptiveThrottlingBreaker] for details.
+//
+// A zero AdaptiveThrottlingBreaker is rejected by [NewCircuit].
+type AdaptiveThrottlingBreaker struct {
+	windowSize      time.Duration
+	k               float64
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	currentStart    atomic.Int64 // monotonic nanoseconds since start (see nowNanos)
+	currentRequests atomic.Int64
+	currentAccepts  atomic.Int64
+	lastRequests    atomic.Int64
+	lastAccepts     atomic.Int64
+}
+
+// NewAdaptiveThrottlingBreaker creates a new [AdaptiveThrottlingBreaker] with the given window size and multiplier,
+// implementing client-side adaptive throttling as described in the Google SRE book
+// (https://sre.google/sre-book/handling-overload/#eq2101).
+//
+// The breaker counts all requests (including the ones it rejected) and all accepted requests (i.e. successes) over
+// the window and rejects each call with a probability of:
+//
+//	max(0, (requests - k*accepts) / (requests + 1))
+//
+// Rejected calls return [ErrCircuitOpen] and are not observed. Unlike the other breakers, it never opens the circuit,
+// so the traffic recovers smoothly as the success rate increases and no half-open state is needed.
+//
+// The windowSize is the time interval over which requests and accepts are counted. The window is weighted the same
+// way as the one of the [SlidingWindowBreaker].
+//
+// The multiplier k determines how aggressively to throttle: rejections start once the requests exceed k times the
+// accepts. It must be at least 1; lower values throttle earlier. The SRE book recommends 2.
+func NewAdaptiveThrottlingBreaker(windowSize time.Duration, k float64) *AdaptiveThrottlingBreaker {
+	return &AdaptiveThrottlingBreaker{
+		windowSize: windowSize,
+		k:          k,
+	}
+}
+
+// rejectionProbability rotates the windows if necessary, counts the incoming request and returns the probability of it
+// being rejected.
+func (a *AdaptiveThrottlingBreaker) rejectionProbability() float64 {
+	var lastRequests, lastAccepts int64
+
+	currentStartNanos := a.currentStart.Load()
+	sinceStart := sinceNanos(currentStartNanos)
+
+	// Rotate the windows like the [SlidingWindowBreaker] does.
+	if (currentStartNanos == 0 || sinceStart > a.windowSize) && a.currentStart.CompareAndSwap(currentStartNanos, nowNanos()) {
+		sinceStart = 0
+		lastRequests = a.lastRequests.Swap(a.currentRequests.Swap(0))
+		lastAccepts = a.lastAccepts.Swap(a.currentAccepts.Swap(0))
+	} else {
+		lastRequests = a.lastRequests.Load()
+		lastAccepts = a.lastAccepts.Load()
+	}
+
+	// The incoming request itself does not count yet, so the first requests are never rejected.
+	currentRequests := a.currentRequests.Add(1) - 1
+	currentAccepts := a.currentAccepts.Load()
+
+	lastWindowWeight := lastWindowShare(a.windowSize, sinceStart)
+
+	requests := float64(lastRequests)*lastWindowWeight + float64(currentRequests)
+	accepts := float64(lastAccepts)*lastWindowWeight + float64(currentAccepts)
+
+	if requests < float64(a.minimumRequests) {
+		return 0
+	}
+
+	return max(0, (requests-a.k*accepts)/(requests+1))
+}
+
+func (a *AdaptiveThrottlingBreaker) admit() bool {
+	p := a.rejectionProbability()
+	return p == 0 || rand.Float64() >= p
+}
+
+func (a *AdaptiveThrottlingBreaker) observe(_, failure bool) StateChange {
+	if !failure {
+		a.currentAccepts.Add(1)
+	}
+
+	return StateChangeNone
+}
+
+// apply implements Option.
+func (a *AdaptiveThrottlingBreaker) apply(o *options) error {
+	if a.windowSize <= 0 {
+		return fmt.Errorf("AdaptiveThrottlingBreaker window size must be positive")
+	}
+
+	if a.k < 1 {
+		return fmt.Errorf("AdaptiveThrottlingBreaker multiplier must be at least 1")
+	}
+
+	a.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// BayesianBreaker is a [Breaker] that opens on a statistically significant failure rate. See [NewBayesianBreaker] for
+// details.
+//
+// A zero BayesianBreaker is rejected by [NewCircuit].
+type BayesianBreaker struct {
+	threshold       float64
+	closeThreshold  float64 // see [EWMABreaker.WithCloseThreshold]
+	confidence      float64
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	window rollingWindow
+}
+
+// NewBayesianBreaker creates a new [BayesianBreaker] with the given window size, failure rate threshold and
+// confidence.
+//
+// The breaker models the failure probability as a Beta distribution, starting from a uniform prior and updated with the
+// failures and successes observed in the window. It only opens once the lower bound of the (equal-tailed) credible
+// interval for the given confidence exceeds the threshold, i.e. once the failure rate is above the threshold with
+// sufficient certainty. This prevents opening on few calls (e.g. 1 failure out of 2), while still reacting quickly with
+// more evidence (e.g. 50 failures out of 100), making it well suited for low-traffic circuits.
+//
+// The window works the same way as the one of the [SlidingWindowBreaker], including the constraints on the half-open
+// delay (see [NewSlidingWindowBreaker]). A successful half-open probe closes the circuit.
+//
+// The windowSize is the time interval over which to count the failures.
+//
+// The failureThreshold is the failure rate above which the breaker should open, between 0 (exclusive) and 1.
+//
+// The confidence is the probability mass of the credible interval, between 0 and 1 (exclusive), e.g. 0.95. Higher values
+// require more evidence to open.
+func NewBayesianBreaker(windowSize time.Duration, failureThreshold, confidence float64) *BayesianBreaker {
+	return &BayesianBreaker{
+		threshold:      failureThreshold,
+		closeThreshold: failureThreshold,
+		confidence:     confidence,
+		window:         rollingWindow{size: windowSize},
+	}
+}
+
+// WithCloseThreshold sets a separate failure rate at or below which the breaker closes the circuit again and returns
+// the breaker. See [EWMABreaker.WithCloseThreshold] for details.
+func (b *BayesianBreaker) WithCloseThreshold(threshold float64) *BayesianBreaker {
+	b.closeThreshold = threshold
+	return b
+}
+
+// lowerBoundAbove reports whether the lower bound of the credible interval lies above the given failure rate.
+func (b *BayesianBreaker) lowerBoundAbove(failureRate, failures, total float64) bool {
+	// The lower bound lies above the failure rate iff less than half of the remaining probability mass lies below it.
+	// Beta(1, 1) is the uniform prior.
+	return regularizedIncompleteBeta(failureRate, 1+failures, 1+total-failures) < (1-b.confidence)/2
+}
+
+func (b *BayesianBreaker) observe(halfOpen, failure bool) StateChange {
+	if !failure && halfOpen {
+		return StateChangeClose
+	}
+
+	failures, total := b.window.add(failure)
+
+	if b.lowerBoundAbove(b.threshold, failures, total) {
+		if !halfOpen && total < float64(b.minimumRequests) {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else if b.closeThreshold == b.threshold || !b.lowerBoundAbove(b.closeThreshold, failures, total) {
+		return StateChangeClose
+	}
+
+	return StateChangeNone
+}
+
+// apply implements Option.
+func (b *BayesianBreaker) apply(o *options) error {
+	// Any failure rate is above a zero threshold with certainty, which would open on the very first observation.
+	if b.threshold <= 0 || b.threshold > 1 {
+		return fmt.Errorf("BayesianBreaker threshold must be between 0 (exclusive) and 1")
+	}
+
+	if b.confidence <= 0 || b.confidence >= 1 {
+		return fmt.Errorf("BayesianBreaker confidence must be between 0 and 1 (exclusive)")
+	}
+
+	if b.window.size <= 0 {
+		return fmt.Errorf("BayesianBreaker window size must be positive")
+	}
+
+	if err := windowHalfOpenDelay(o, b.window.size); err != nil {
+		return fmt.Errorf("BayesianBreaker %w", err)
+	}
+
+	if err := checkCloseThreshold(b.closeThreshold, b.threshold); err != nil {
+		return fmt.Errorf("BayesianBreaker %w", err)
+	}
+
+	b.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// CUSUMBreaker is a [Breaker] that opens on a sudden upward shift of the failure rate. See [NewCUSUMBreaker] for
+// details.
+//
+// A zero CUSUMBreaker is rejected by [NewCircuit].
+type CUSUMBreaker struct {
+	decay           float64
+	slack           float64
+	limit           float64
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	baseline     atomic.Uint64 // float64 bits
+	sum          atomic.Uint64 // float64 bits
+	observations atomic.Int64  // only counted up to minimumRequests
+}
+
+// NewCUSUMBreaker creates a new [CUSUMBreaker] with the given baseline sample count, slack and limit. It runs a CUSUM
+// change-point detector (https://en.wikipedia.org/wiki/CUSUM) against a learned baseline failure rate.
+//
+// ⚠️ This is an observation-based breaker, which means it requires new calls to be able to update its state, and
+// therefore REQUIRES the circuit to set a half-open threshold via [WithHalfOpenDelay]. Otherwise an open circuit will
+// never observe any successes and thus never close.
+//
+// Instead of comparing the failure rate to a fixed threshold, the breaker learns the normal failure rate of the
+// circuit as an exponentially weighted moving average (like the [EWMABreaker]) and accumulates how much each
+// observation exceeds it (minus the slack). A sustained shift of the failure rate lets the cumulative sum grow until it
+// exceeds the limit and opens the circuit, while sporadic failures are absorbed by the slack. This allows using the same
+// configuration for circuits with very different normal failure rates. The baseline is only learned while no shift is
+// suspected (i.e. while the cumulative sum is below half the limit), so an ongoing outage does not become the new
+// normal.
+//
+// The baselineSampleCount must be at least 1 and determines how fast the baseline adapts, like the sample count of
+// the [EWMABreaker].
+//
+// The slack is the increase of the failure rate over the baseline that is tolerated (e.g. 0.05), at least 0.
+//
+// The limit is the cumulative sum above which the breaker should open (e.g. 5), which must be positive. Roughly, a
+// failure rate exceeding the baseline by slack+x opens the circuit after limit/x calls.
+func NewCUSUMBreaker(baselineSampleCount uint, slack, limit float64) *CUSUMBreaker {
+	return &CUSUMBreaker{
+		decay: 2 / (float64(baselineSampleCount) + 1),
+		slack: slack,
+		limit: limit,
+	}
+}
+
+// Baseline returns the currently learned baseline failure rate. It is meant for debugging purposes.
+func (c *CUSUMBreaker) Baseline() float64 {
+	return fromStore(c.baseline.Load())
+}
+
+// CumulativeSum returns the current cumulative sum of the failure rate exceeding the baseline. It is meant for
+// debugging purposes.
+func (c *CUSUMBreaker) CumulativeSum() float64 {
+	return fromStore(c.sum.Load())
+}
+
+func (c *CUSUMBreaker) observe(halfOpen, failure bool) StateChange {
+	if !failure && halfOpen {
+		c.sum.Store(toStore(0))
+		return StateChangeClose
+	}
+
+	var value = 0.0
+	if failure {
+		value = 1.0
+	}
+
+	baseline := c.Baseline()
+
+	var sum float64
+	for {
+		old := c.sum.Load()
+		sum = max(0, fromStore(old)+value-baseline-c.slack)
+		if c.sum.CompareAndSwap(old, toStore(sum)) {
+			break
+		}
+	}
+
+	if sum <= c.limit/2 {
+		// Concurrent updates may overwrite each other, which only slightly slows down learning.
+		c.baseline.Store(toStore(value*c.decay + baseline*(1-c.decay)))
+	}
+
+	observations := c.observations.Load()
+	if observations < c.minimumRequests {
+		observations = c.observations.Add(1)
+	}
+
+	switch {
+	case sum > c.limit:
+		if !halfOpen && observations < c.minimumRequests {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	case sum == 0:
+		return StateChangeClose
+	default:
+		return StateChangeNone
+	}
+}
+
+// apply implements Option.
+func (c *CUSUMBreaker) apply(o *options) error {
+	if o.halfOpenDelay == 0 {
+		return fmt.Errorf("CUSUMBreaker requires a half-open delay")
+	}
+
+	// see [EWMABreaker.apply]; unlike the EWMABreaker, a zero decay is rejected, too
+	if c.decay <= 0 || c.decay > 1 {
+		return fmt.Errorf("CUSUMBreaker requires a baseline sample count of at least 1")
+	}
+
+	if c.slack < 0 {
+		return fmt.Errorf("CUSUMBreaker slack must not be negative")
+	}
+
+	if c.limit <= 0 {
+		return fmt.Errorf("CUSUMBreaker limit must be positive")
+	}
+
+	c.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// BurnRateWindow configures one of the windows of a [BurnRateBreaker].
+type BurnRateWindow struct {
+	// Size is the time interval over which to calculate the burn rate.
+	Size time.Duration
+	// Factor is the burn rate above which the window considers the error budget to be burning too fast (e.g. 14.4,
+	// which would exhaust a 30 day error budget within ~2 days).
+	Factor float64
+}
+
+// BurnRateBreaker is a [Breaker] that opens when the error budget of an SLO burns too fast. See [NewBurnRateBreaker]
+// for details.
+//
+// A zero BurnRateBreaker is rejected by [NewCircuit].
+type BurnRateBreaker struct {
+	errorBudget     float64 // 1 - SLO
+	shortFactor     float64
+	longFactor      float64
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	short rollingWindow
+	long  rollingWindow
+}
+
+// NewBurnRateBreaker creates a new [BurnRateBreaker] for the given SLO, evaluated over a short and a long window,
+// mirroring the multi-window, multi-burn-rate alerts from the Google SRE workbook
+// (https://sre.google/workbook/alerting-on-slos/#6-multiwindow-multi-burn-rate-alerts).
+//
+// The burn rate is the failure rate relative to the error budget (1 - SLO): a burn rate of 1 uses up the budget
+// exactly within the SLO period. The breaker opens once the burn rates of both windows exceed their factors: the long
+// window ensures enough of the budget has been burnt to be significant, while the short one ensures the circuit closes
+// quickly once the failures stop.
+//
+// Both windows work the same way as the one of the [SlidingWindowBreaker]. The half-open delay (see
+// [WithHalfOpenDelay]) defaults to the short window's size and may not exceed it. A successful half-open probe closes
+// the circuit.
+//
+// The slo is the targeted success rate, between 0 and 1 (exclusive), e.g. 0.999.
+//
+// The short window must not be larger than the long window. Both factors must be positive.
+func NewBurnRateBreaker(slo float64, short, long BurnRateWindow) *BurnRateBreaker {
+	return &BurnRateBreaker{
+		errorBudget: 1 - slo,
+		shortFactor: short.Factor,
+		longFactor:  long.Factor,
+		short:       rollingWindow{size: short.Size},
+		long:        rollingWindow{size: long.Size},
+	}
+}
+
+func (b *BurnRateBreaker) observe(halfOpen, failure bool) StateChange {
+	if !failure && halfOpen {
+		return StateChangeClose
+	}
+
+	shortFailures, shortTotal := b.short.add(failure)
+	longFailures, longTotal := b.long.add(failure)
+
+	shortBurnRate := shortFailures / shortTotal / b.errorBudget
+	longBurnRate := longFailures / longTotal / b.errorBudget
+
+	if shortBurnRate > b.shortFactor && longBurnRate > b.longFactor {
+		if !halfOpen && shortTotal < float64(b.minimumRequests) {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else {
+		return StateChangeClose
+	}
+}
+
+// apply implements Option.
+func (b *BurnRateBreaker) apply(o *options) error {
+	if b.errorBudget <= 0 || b.errorBudget >= 1 {
+		return fmt.Errorf("BurnRateBreaker SLO must be between 0 and 1 (exclusive)")
+	}
+
+	if b.shortFactor <= 0 || b.longFactor <= 0 {
+		return fmt.Errorf("BurnRateBreaker factors must be positive")
+	}
+
+	if b.short.size <= 0 || b.short.size > b.long.size {
+		return fmt.Errorf("BurnRateBreaker short window (%s) must be positive and not exceed the long window (%s)", b.short.size, b.long.size)
+	}
+
+	if err := windowHalfOpenDelay(o, b.short.size); err != nil {
+		return fmt.Errorf("BurnRateBreaker %w of the short window", err)
+	}
+
+	b.minimumRequests = o.minimumRequests
+
+	return nil
+}
+
+// FailureCountBreaker is a [Breaker] that opens on an absolute number of failures within a time window. See
+// [NewFailureCountBreaker] for details.
+//
+// A zero FailureCountBreaker is rejected by [NewCircuit].
+type FailureCountBreaker struct {
+	maxFailures     float64
+	minimumRequests int64 // see [WithMinimumRequests]
+
+	// State
+
+	window rollingWindow
+}
+
+// NewFailureCountBreaker creates a new [FailureCountBreaker] with the given window size and maximum number of
+// failures.
+//
+// Unlike the rate-based breakers, the number of successes does not matter: the breaker opens once more than
+// maxFailures failures have been observed within the window, no matter how many calls succeeded in between. This
+// suits expensive calls, where every failure counts.
+//
+// The window works the same way as the one of the [SlidingWindowBreaker] (i.e. failures of the last window count
+// proportionally), including the constraints on the half-open delay (see [NewSlidingWindowBreaker]). A successful
+// half-open probe closes the circuit.
+//
+// The windowSize is the time interval over which to count the failures.
+//
+// The maxFailures is the number of failures within the window above which the breaker should open.
+func NewFailureCountBreaker(windowSize time.Duration, maxFailures uint) *FailureCountBreaker {
+	return &FailureCountBreaker{
+		maxFailures: float64(maxFailures),
+		window:      rollingWindow{size: windowSize},
+	}
+}
+
+func (f *FailureCountBreaker) observe(halfOpen, failure bool) StateChange {
+	if !failure && halfOpen {
+		return StateChangeClose
+	}
+
+	failures, total := f.window.add(failure)
+
+	if failures > f.maxFailures {
+		if !halfOpen && total < float64(f.minimumRequests) {
+			return StateChangeNone
+		}
+		return StateChangeOpen
+	} else {
+		return StateChangeClose
+	}
+}
+
+// apply implements Option.
+func (f *FailureCountBreaker) apply(o *options) error {
+	if f.window.size <= 0 {
+		return fmt.Errorf("FailureCountBreaker window size must be positive")
+	}
+
+	if err := windowHalfOpenDelay(o, f.window.size); err != nil {
+		return fmt.Errorf("FailureCountBreaker %w", err)
+	}
+
+	f.minimumRequests = o.minimumRequests
+
 	return nil
 }
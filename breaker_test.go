@@ -6,12 +6,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEWMABreaker_zero_value_does_not_open(t *testing.T) {
 	b := &EWMABreaker{}
 	s := b.observe(false, true)
-	assert.NotEqual(t, stateChangeOpen, s)
+	assert.NotEqual(t, StateChangeOpen, s)
 }
 
 func TestEWMABreaker_zero_value_does_not_panic(t *testing.T) {
@@ -32,6 +33,55 @@ func TestEWMABreaker_sample_count_of_0_is_rejected(t *testing.T) {
 	assert.Error(t, err, "expected a sample count of 0 to be rejected")
 }
 
+func TestHalfLifeEWMABreaker_decays_with_time(t *testing.T) {
+	b := NewHalfLifeEWMABreaker(time.Hour, 0.3)
+
+	assert.Equal(t, StateChangeOpen, b.observe(false, true), "the first observation seeds the failure rate")
+
+	// simulate passage of time: pretend the previous observation happened one half-life ago
+	b.lastObserved.Store(nowNanos() - int64(b.halfLife))
+	assert.Equal(t, StateChangeOpen, b.observe(false, false))
+	assert.InDelta(t, 0.5, fromStore(b.failureRate.Load()), 0.01, "the failure should have lost half its weight")
+
+	b.lastObserved.Store(nowNanos() - int64(b.halfLife))
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+	assert.InDelta(t, 0.25, fromStore(b.failureRate.Load()), 0.01)
+}
+
+func TestHalfLifeEWMABreaker_rapid_observations_barely_decay(t *testing.T) {
+	b := NewHalfLifeEWMABreaker(time.Hour, 0.3)
+
+	b.observe(false, true)
+	for range 100 {
+		b.observe(false, false)
+	}
+
+	assert.Greater(t, fromStore(b.failureRate.Load()), 0.99, "observations within a fraction of the half-life should barely count")
+}
+
+func TestEWMABreaker_weighted_failure_counts_like_repeated_failures(t *testing.T) {
+	weighted := NewEWMABreaker(10, 0.9)
+	repeated := NewEWMABreaker(10, 0.9)
+
+	weighted.observe(false, false)
+	repeated.observe(false, false)
+
+	weighted.observeCall(false, true, 3, 0)
+	for range 3 {
+		repeated.observe(false, true)
+	}
+
+	assert.InDelta(t, fromStore(repeated.failureRate.Load()), fromStore(weighted.failureRate.Load()), 1e-9)
+}
+
+func TestEWMABreaker_weighted_first_failure(t *testing.T) {
+	for weight, want := range map[float64]float64{0: 0, 0.5: 0.5, 3: 1} {
+		b := NewEWMABreaker(10, 0.9)
+		b.observeCall(false, true, weight, 0)
+		assert.InDelta(t, want, fromStore(b.failureRate.Load()), 1e-9, "weight %v", weight)
+	}
+}
+
 // testSeed is a fixed seed for the per-subtest RNG so the statistically-driven EWMA stages are deterministic and
 // reproducible across runs (chosen so the "constant low/high failure rate" cases land on their expected state).
 const testSeed = 0
@@ -45,7 +95,7 @@ func TestBreaker_Observe_State(t *testing.T) {
 		calls           int
 		failureFunc     func(r *rand.Rand, call int) bool
 		waitForHalfOpen bool        // whether to put circuit in half-open BEFORE observing the call's result
-		wantStateChange stateChange // expected state change at the END of the stage
+		wantStateChange StateChange // expected state change at the END of the stage
 	}
 	tests := []struct {
 		name     string
@@ -57,9 +107,13 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(10, 0.3),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.3),
+				"failurecount":  NewFailureCountBreaker(10*time.Second, 10),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.3),
+				"consecutive":   NewConsecutiveFailuresBreaker(3),
+				"countwindow":   NewCountWindowBreaker(1000, 0.3),
 			},
 			stages: []stages{
-				{calls: 1, failureFunc: alwaysSuccessful, wantStateChange: stateChangeClose},
+				{calls: 1, failureFunc: alwaysSuccessful, wantStateChange: StateChangeClose},
 			},
 		},
 		{
@@ -67,9 +121,14 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(10, 0.3),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.3),
+				"failurecount":  NewFailureCountBreaker(10*time.Second, 10),
+				"bayesian":      NewBayesianBreaker(10*time.Second, 0.3, 0.95),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.3),
+				"consecutive":   NewConsecutiveFailuresBreaker(3),
+				"countwindow":   NewCountWindowBreaker(1000, 0.3),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: stateChangeClose},
+				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: StateChangeClose},
 			},
 		},
 		{
@@ -77,9 +136,14 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(10, 0.9),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.9),
+				"failurecount":  NewFailureCountBreaker(10*time.Second, 10),
+				"bayesian":      NewBayesianBreaker(10*time.Second, 0.9, 0.95),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.9),
+				"consecutive":   NewConsecutiveFailuresBreaker(3),
+				"countwindow":   NewCountWindowBreaker(1000, 0.9),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
 			},
 		},
 		{
@@ -87,10 +151,11 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma": NewEWMABreaker(10, 0.2),
 				// sliding window is not affected by ordering
+				"countwindow": NewCountWindowBreaker(100, 0.2),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
-				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: stateChangeClose},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
+				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: StateChangeClose},
 			},
 		},
 		{
@@ -100,28 +165,32 @@ func TestBreaker_Observe_State(t *testing.T) {
 				// sliding window is not affected by ordering
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: stateChangeClose},
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
+				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: StateChangeClose},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
 			},
 		},
 		{
 			name: "just above threshold opens",
 			breakers: map[string]Breaker{
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.5),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.5),
+				"countwindow":   NewCountWindowBreaker(1000, 0.5),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: stateChangeClose},
-				{calls: 101, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
+				{calls: 100, failureFunc: alwaysSuccessful, wantStateChange: StateChangeClose},
+				{calls: 101, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
 			},
 		},
 		{
 			name: "just below threshold stays closed",
 			breakers: map[string]Breaker{
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.5),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.5),
+				"countwindow":   NewCountWindowBreaker(1000, 0.5),
 			},
 			stages: []stages{
-				{calls: 101, failureFunc: alwaysSuccessful, wantStateChange: stateChangeClose},
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeClose},
+				{calls: 101, failureFunc: alwaysSuccessful, wantStateChange: StateChangeClose},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeClose},
 			},
 		},
 		{
@@ -129,9 +198,12 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(50, 0.2),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.2),
+				"bayesian":      NewBayesianBreaker(10*time.Second, 0.2, 0.95),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.2),
+				"countwindow":   NewCountWindowBreaker(1000, 0.2),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: func(r *rand.Rand, _ int) bool { return r.Float64() < 0.1 }, wantStateChange: stateChangeClose},
+				{calls: 100, failureFunc: func(r *rand.Rand, _ int) bool { return r.Float64() < 0.1 }, wantStateChange: StateChangeClose},
 			},
 		},
 		{
@@ -139,9 +211,11 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(50, 0.2),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.2),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.2),
+				"countwindow":   NewCountWindowBreaker(1000, 0.2),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: func(r *rand.Rand, _ int) bool { return r.Float64() < 0.4 }, wantStateChange: stateChangeOpen},
+				{calls: 100, failureFunc: func(r *rand.Rand, _ int) bool { return r.Float64() < 0.4 }, wantStateChange: StateChangeOpen},
 			},
 		},
 		{
@@ -149,10 +223,15 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(50, 0.1),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.1),
+				"failurecount":  NewFailureCountBreaker(10*time.Second, 10),
+				"bayesian":      NewBayesianBreaker(10*time.Second, 0.1, 0.95),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.1),
+				"consecutive":   NewConsecutiveFailuresBreaker(3),
+				"countwindow":   NewCountWindowBreaker(1000, 0.1),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
-				{calls: 1, failureFunc: alwaysSuccessful, waitForHalfOpen: true, wantStateChange: stateChangeClose},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
+				{calls: 1, failureFunc: alwaysSuccessful, waitForHalfOpen: true, wantStateChange: StateChangeClose},
 			},
 		},
 		{
@@ -160,10 +239,15 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(50, 0.1),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.1),
+				"failurecount":  NewFailureCountBreaker(10*time.Second, 10),
+				"bayesian":      NewBayesianBreaker(10*time.Second, 0.1, 0.95),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.1),
+				"consecutive":   NewConsecutiveFailuresBreaker(3),
+				"countwindow":   NewCountWindowBreaker(1000, 0.1),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
-				{calls: 1, failureFunc: alwaysFailure, waitForHalfOpen: true, wantStateChange: stateChangeOpen},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
+				{calls: 1, failureFunc: alwaysFailure, waitForHalfOpen: true, wantStateChange: StateChangeOpen},
 			},
 		},
 		{
@@ -173,11 +257,13 @@ func TestBreaker_Observe_State(t *testing.T) {
 			breakers: map[string]Breaker{
 				"ewma":          NewEWMABreaker(50, 0.1),
 				"slidingwindow": NewSlidingWindowBreaker(10*time.Second, 0.1),
+				"bucketed":      NewBucketedSlidingWindowBreaker(10*time.Second, 10, 0.1),
+				"countwindow":   NewCountWindowBreaker(1000, 0.1),
 			},
 			stages: []stages{
-				{calls: 100, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
-				{calls: 1, failureFunc: alwaysSuccessful, waitForHalfOpen: true, wantStateChange: stateChangeClose},
-				{calls: 1, failureFunc: alwaysFailure, wantStateChange: stateChangeOpen},
+				{calls: 100, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
+				{calls: 1, failureFunc: alwaysSuccessful, waitForHalfOpen: true, wantStateChange: StateChangeClose},
+				{calls: 1, failureFunc: alwaysFailure, wantStateChange: StateChangeOpen},
 			},
 		},
 	}
@@ -190,7 +276,7 @@ func TestBreaker_Observe_State(t *testing.T) {
 				rng := rand.New(rand.NewSource(testSeed))
 
 				for _, s := range tt.stages {
-					var lastStateChange stateChange
+					var lastStateChange StateChange
 
 					for i := 1; i <= s.calls; i++ {
 						failure := s.failureFunc(rng, i)
@@ -201,6 +287,16 @@ func TestBreaker_Observe_State(t *testing.T) {
 						case *SlidingWindowBreaker:
 							lastStateChange = ignoreNone(lastStateChange, b.observe(s.waitForHalfOpen && i == s.calls, failure))
 							// t.Logf("%s: sample %d: failure %v: => %v", tt.name, i, failure, b.circuit.State())
+						case *BucketedSlidingWindowBreaker:
+							lastStateChange = ignoreNone(lastStateChange, b.observe(s.waitForHalfOpen && i == s.calls, failure))
+						case *CountWindowBreaker:
+							lastStateChange = ignoreNone(lastStateChange, b.observe(s.waitForHalfOpen && i == s.calls, failure))
+						case *BayesianBreaker:
+							lastStateChange = ignoreNone(lastStateChange, b.observe(s.waitForHalfOpen && i == s.calls, failure))
+						case *FailureCountBreaker:
+							lastStateChange = ignoreNone(lastStateChange, b.observe(s.waitForHalfOpen && i == s.calls, failure))
+						case *ConsecutiveFailuresBreaker:
+							lastStateChange = ignoreNone(lastStateChange, b.observe(s.waitForHalfOpen && i == s.calls, failure))
 						}
 					}
 
@@ -223,22 +319,281 @@ func TestSlidingWindowBreaker_window_start_is_stable_within_window(t *testing.T)
 	assert.Equal(t, windowStart, b.currentStart.Load(), "observations within the window must not move its start")
 }
 
+func TestSlidingWindowBreaker_weighted_failures(t *testing.T) {
+	b := NewSlidingWindowBreaker(time.Minute, 0.5)
+
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+	assert.Equal(t, StateChangeClose, b.observeCall(false, true, 0.5, 0), "failure rate should be 0.5/1.5")
+	assert.Equal(t, StateChangeOpen, b.observeCall(false, true, 2, 0), "failure rate should be 2.5/3.5")
+}
+
+func TestSlidingWindowBreaker_weighted_failures_do_not_count_towards_minimum(t *testing.T) {
+	b := NewSlidingWindowBreaker(time.Minute, 0.5)
+	require.NoError(t, b.apply(&options{minimumRequests: 3}))
+
+	assert.Equal(t, StateChangeNone, b.observeCall(false, true, 3, 0), "a single call must not reach the minimum")
+	assert.Equal(t, StateChangeNone, b.observeCall(false, true, 3, 0))
+	assert.Equal(t, StateChangeOpen, b.observeCall(false, true, 3, 0))
+}
+
 func TestSlidingWindowBreaker_rotates_windows_after_windowSize(t *testing.T) {
 	b := NewSlidingWindowBreaker(time.Minute, 0.5)
 
-	assert.Equal(t, stateChangeOpen, b.observe(false, true))
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
 
 	// simulate passage of time: pretend the current window started more than a windowSize ago
 	b.currentStart.Store(nowNanos() - int64(b.windowSize+time.Second))
 
 	b.observe(false, false)
-	assert.EqualValues(t, 1, b.lastFailureCount.Load(), "failures should have been rotated into the last window")
-	assert.EqualValues(t, 0, b.currentFailureCount.Load())
+	assert.EqualValues(t, 1, fromStore(b.lastFailureCount.Load()), "failures should have been rotated into the last window")
+	assert.EqualValues(t, 0, fromStore(b.currentFailureCount.Load()))
+}
+
+func TestConsecutiveFailuresBreaker_opens_after_streak(t *testing.T) {
+	b := NewConsecutiveFailuresBreaker(3)
+
+	assert.Equal(t, StateChangeNone, b.observe(false, true))
+	assert.Equal(t, StateChangeNone, b.observe(false, true))
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+}
+
+func TestConsecutiveFailuresBreaker_success_resets_streak(t *testing.T) {
+	b := NewConsecutiveFailuresBreaker(2)
+
+	assert.Equal(t, StateChangeNone, b.observe(false, true))
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+	assert.Equal(t, StateChangeNone, b.observe(false, true), "streak should have been reset by the success")
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+}
+
+func TestConsecutiveFailuresBreaker_failure_count_of_0_is_rejected(t *testing.T) {
+	_, err := NewCircuit(NewConsecutiveFailuresBreaker(0), WithHalfOpenDelay(time.Second))
+	assert.Error(t, err, "expected a failure count of 0 to be rejected")
+}
+
+func TestBucketedSlidingWindowBreaker_ignores_buckets_outside_window(t *testing.T) {
+	b := NewBucketedSlidingWindowBreaker(time.Minute, 10, 0.5)
+
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+
+	// simulate passage of time: move all buckets a full window into the past
+	for i := range b.buckets {
+		b.buckets[i].epoch.Add(-int64(len(b.buckets)))
+	}
+
+	assert.Equal(t, StateChangeClose, b.observe(false, false), "the failure should have dropped out of the window")
+}
+
+func TestBucketedSlidingWindowBreaker_zero_buckets_is_rejected(t *testing.T) {
+	_, err := NewCircuit(NewBucketedSlidingWindowBreaker(time.Minute, 0, 0.5))
+	assert.Error(t, err, "expected 0 buckets to be rejected")
+}
+
+func TestCountWindowBreaker_evicts_oldest_outcome(t *testing.T) {
+	b := NewCountWindowBreaker(2, 0.5)
+
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+	assert.Equal(t, StateChangeClose, b.observe(false, false), "the failure should have dropped out of the window")
+	assert.EqualValues(t, 0, b.failureCount.Load())
+}
+
+func TestCountWindowBreaker_window_size_of_0_is_rejected(t *testing.T) {
+	_, err := NewCircuit(NewCountWindowBreaker(0, 0.5), WithHalfOpenDelay(time.Second))
+	assert.Error(t, err, "expected a window size of 0 to be rejected")
+}
+
+func TestSlowCallBreaker_opens_on_slow_calls(t *testing.T) {
+	b := NewSlowCallBreaker(time.Minute, time.Second, 0.5)
+
+	assert.Equal(t, StateChangeClose, b.observeCall(false, true, 1, time.Millisecond), "fast failures are not slow")
+	assert.Equal(t, StateChangeClose, b.observeCall(false, false, 1, 2*time.Second))
+	assert.Equal(t, StateChangeOpen, b.observeCall(false, false, 1, 2*time.Second))
+	assert.Equal(t, StateChangeClose, b.observeCall(true, false, 1, time.Millisecond), "a fast probe should close")
+}
+
+func TestLatencyPercentileBreaker_opens_on_percentile_above_limit(t *testing.T) {
+	b := NewLatencyPercentileBreaker(time.Minute, 0.9, 100*time.Millisecond)
+
+	for range 95 {
+		assert.Equal(t, StateChangeClose, b.observeCall(false, false, 1, 10*time.Millisecond))
+	}
+	assert.InEpsilon(t, 10*time.Millisecond, b.Percentile(), 0.05)
+
+	var lastStateChange StateChange
+	for range 15 {
+		lastStateChange = b.observeCall(false, false, 1, time.Second)
+	}
+	assert.Equal(t, StateChangeOpen, lastStateChange)
+	assert.InEpsilon(t, time.Second, b.Percentile(), 0.05)
+}
+
+func TestLatencyPercentileBreaker_rotates_windows_after_windowSize(t *testing.T) {
+	b := NewLatencyPercentileBreaker(time.Minute, 0.9, 100*time.Millisecond)
+
+	assert.Equal(t, StateChangeOpen, b.observeCall(false, false, 1, time.Second))
+
+	// simulate passage of time: pretend the current window started more than two windowSizes ago
+	b.currentStart.Store(nowNanos() - int64(2*b.windowSize+time.Second))
+	b.observeCall(false, false, 1, time.Millisecond) // rotate once; the slow call is now in the last window
+	b.currentStart.Store(nowNanos() - int64(2*b.windowSize+time.Second))
+
+	assert.Equal(t, StateChangeClose, b.observeCall(false, false, 1, time.Millisecond), "the slow call should have dropped out")
+}
+
+func TestAdaptiveThrottlingBreaker_rejection_probability(t *testing.T) {
+	b := NewAdaptiveThrottlingBreaker(time.Minute, 2)
+
+	for range 100 {
+		require.True(t, b.admit(), "successful calls should never be throttled")
+		assert.Equal(t, StateChangeNone, b.observe(false, false))
+	}
+	assert.Zero(t, b.rejectionProbability())
+
+	for range 1000 {
+		if b.admit() {
+			assert.Equal(t, StateChangeNone, b.observe(false, true))
+		}
+	}
+	// requests ≈ 1100, accepts = 100: (1100 - 2*100) / 1101
+	assert.InDelta(t, 0.82, b.rejectionProbability(), 0.01)
+}
+
+func TestBreaker_close_threshold_keeps_state_in_between(t *testing.T) {
+	b := NewCountWindowBreaker(10, 0.5).WithCloseThreshold(0.2)
+	_, err := NewCircuit(b, WithHalfOpenDelay(time.Second))
+	require.NoError(t, err)
+
+	var lastStateChange StateChange
+	for range 10 {
+		lastStateChange = b.observe(false, true)
+	}
+	require.Equal(t, StateChangeOpen, lastStateChange)
+
+	// failure rate drops from 0.9 to 0.3: above the close threshold
+	for range 7 {
+		lastStateChange = ignoreNone(lastStateChange, b.observe(false, false))
+	}
+	assert.Equal(t, StateChangeOpen, lastStateChange, "expected no state change above the close threshold")
+
+	// failure rate drops to 0.2
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+}
+
+func TestBayesianBreaker_requires_significance(t *testing.T) {
+	b := NewBayesianBreaker(time.Minute, 0.3, 0.95)
+
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+	assert.Equal(t, StateChangeClose, b.observe(false, true), "1 of 2 failures is not significant")
+
+	var lastStateChange StateChange
+	for i := range 98 {
+		lastStateChange = b.observe(false, i%2 == 0)
+	}
+	assert.Equal(t, StateChangeOpen, lastStateChange, "50 of 100 failures is significantly above 0.3")
+}
+
+func TestBayesianBreaker_rate_near_threshold_stays_closed(t *testing.T) {
+	b := NewBayesianBreaker(time.Minute, 0.3, 0.95)
+
+	var lastStateChange StateChange
+	for i := range 100 {
+		lastStateChange = b.observe(false, i%3 == 0)
+	}
+	assert.Equal(t, StateChangeClose, lastStateChange, "34 of 100 failures is not significantly above 0.3")
+}
+
+func TestCUSUMBreaker_detects_shift_from_baseline(t *testing.T) {
+	// failures are spread evenly: every n-th call fails
+	for name, n := range map[string]int{"0%": 0, "10%": 10} {
+		t.Run(name, func(t *testing.T) {
+			b := NewCUSUMBreaker(100, 0.1, 3)
+
+			var lastStateChange StateChange
+			for i := range 1000 {
+				lastStateChange = ignoreNone(lastStateChange, b.observe(false, n > 0 && i%n == 0))
+			}
+			require.Equal(t, StateChangeClose, lastStateChange, "the baseline failure rate should not open")
+			if n > 0 {
+				assert.InDelta(t, 1/float64(n), b.Baseline(), 0.05)
+			} else {
+				assert.Zero(t, b.Baseline())
+			}
+
+			for range 20 {
+				lastStateChange = ignoreNone(lastStateChange, b.observe(false, true))
+			}
+			assert.Equal(t, StateChangeOpen, lastStateChange, "a sustained shift should open")
+			assert.Greater(t, b.CumulativeSum(), 3.0)
+
+			assert.Equal(t, StateChangeClose, b.observe(true, false))
+			assert.Zero(t, b.CumulativeSum(), "a successful probe should reset the cumulative sum")
+		})
+	}
+}
+
+func TestCUSUMBreaker_sporadic_failures_are_absorbed(t *testing.T) {
+	b := NewCUSUMBreaker(100, 0.1, 3)
+
+	var lastStateChange StateChange
+	for i := range 100 {
+		lastStateChange = ignoreNone(lastStateChange, b.observe(false, i%20 == 0))
+	}
+	assert.Equal(t, StateChangeClose, lastStateChange)
+}
+
+func TestBurnRateBreaker_requires_both_windows(t *testing.T) {
+	b := NewBurnRateBreaker(0.99,
+		BurnRateWindow{Size: time.Minute, Factor: 10},
+		BurnRateWindow{Size: time.Hour, Factor: 5},
+	)
+
+	var lastStateChange StateChange
+	for range 1000 {
+		lastStateChange = ignoreNone(lastStateChange, b.observe(false, false))
+	}
+	require.Equal(t, StateChangeClose, lastStateChange)
+
+	// simulate passage of time: the short window rotates twice and forgets the successes, the long one does not
+	for range 2 {
+		b.short.currentStart.Store(nowNanos() - int64(b.short.size+time.Second))
+		b.observe(false, false)
+	}
+
+	for range 20 {
+		lastStateChange = ignoreNone(lastStateChange, b.observe(false, true))
+	}
+	assert.Equal(t, StateChangeClose, lastStateChange, "expected the long window's burn rate (~2) to keep the circuit closed")
+
+	for range 40 {
+		lastStateChange = ignoreNone(lastStateChange, b.observe(false, true))
+	}
+	assert.Equal(t, StateChangeOpen, lastStateChange, "expected both burn rates to be exceeded")
+}
+
+func TestBurnRateBreaker_short_window_exceeding_long_window_errors(t *testing.T) {
+	_, err := NewCircuit(NewBurnRateBreaker(0.999,
+		BurnRateWindow{Size: time.Hour, Factor: 14.4},
+		BurnRateWindow{Size: time.Minute, Factor: 14.4},
+	))
+	assert.Error(t, err)
+}
+
+func TestFailureCountBreaker_ignores_successes(t *testing.T) {
+	b := NewFailureCountBreaker(time.Minute, 2)
+
+	var lastStateChange StateChange
+	for i := range 1000 {
+		lastStateChange = b.observe(false, i%500 == 0)
+	}
+	require.Equal(t, StateChangeClose, lastStateChange, "2 failures should not open")
+
+	assert.Equal(t, StateChangeOpen, b.observe(false, true), "the 3rd failure should open, despite 1000 successes")
 }
 
 // ignoreNone is a small helper to skip the "none" state change and only record the last "effective" state change.
-func ignoreNone(old, new stateChange) stateChange {
-	if new == stateChangeNone {
+func ignoreNone(old, new StateChange) StateChange {
+	if new == StateChangeNone {
 		return old
 	}
 	return new
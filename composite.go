@@ -0,0 +1,189 @@
+package hoglet
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// CompositeBreaker is a [Breaker] combining multiple breakers. See [AnyOf] and [AllOf] for details.
+//
+// A zero CompositeBreaker is rejected by [NewCircuit].
+type CompositeBreaker struct {
+	breakers []Breaker
+	all      bool // whether all breakers have to agree to open the circuit (AllOf) or just any (AnyOf)
+
+	voting []bool // whether each breaker may open the circuit at all (see [admissionOnly])
+	voters int    // number of breakers that may open the circuit
+	calls  bool   // whether any breaker takes call details into account (see [observesCalls])
+	admits bool   // whether any breaker may reject calls (see [rejectsCalls])
+
+	// State
+
+	open []atomic.Bool // last effective state of each breaker; true = open
+}
+
+// AnyOf returns a [CompositeBreaker] that opens the circuit as soon as any of the given breakers opens it, and closes it
+// once all of them have closed it again. E.g. combining an error-rate breaker with a [SlowCallBreaker] opens the circuit
+// on too many errors OR too many slow calls.
+//
+// See [AllOf] for details on how the breakers are combined.
+func AnyOf(breakers ...Breaker) *CompositeBreaker {
+	return newCompositeBreaker(breakers, false)
+}
+
+// AllOf returns a [CompositeBreaker] that opens the circuit only once all of the given breakers agree to open it, and
+// closes it as soon as any of them closes it again. E.g. combining a [SlidingWindowBreaker] with a
+// [ConsecutiveFailuresBreaker] opens the circuit only on a high failure rate AND a streak of failures.
+//
+// Every observation is passed on to all breakers, each keeping its own state. Since breakers may not signal a state
+// change on every observation, the composite remembers the last state each breaker asked for. A failed half-open probe
+// always keeps the circuit open.
+//
+// All breakers' options are applied in the given order when creating the circuit, so they may reject the circuit's
+// options (or each other's defaults, e.g. the half-open delay) like they do on their own. Breakers that measure call
+// durations (e.g. [SlowCallBreaker]), weigh failures (see [WithFailureWeight]) or reject calls (e.g.
+// [AdaptiveThrottlingBreaker]) keep doing so; a call is only let through if all breakers admit it. Breakers that never
+// open the circuit but only reject calls (like the [AdaptiveThrottlingBreaker]) do not have to agree, so an AllOf of
+// nothing but such breakers never opens the circuit.
+func AllOf(breakers ...Breaker) *CompositeBreaker {
+	return newCompositeBreaker(breakers, true)
+}
+
+func newCompositeBreaker(breakers []Breaker, all bool) *CompositeBreaker {
+	c := &CompositeBreaker{
+		breakers: breakers,
+		all:      all,
+		voting:   make([]bool, len(breakers)),
+		open:     make([]atomic.Bool, len(breakers)),
+	}
+
+	for i, b := range breakers {
+		if c.voting[i] = !admissionOnly(b); c.voting[i] {
+			c.voters++
+		}
+		c.calls = c.calls || observesCalls(b)
+		c.admits = c.admits || rejectsCalls(b)
+	}
+
+	return c
+}
+
+// observesCalls reports whether the circuit has to pass the details of calls to the given breaker (see [callBreaker]).
+// A [CompositeBreaker] implements callBreaker for its breakers, so it only needs them if any of its breakers does.
+func observesCalls(b Breaker) bool {
+	if c, ok := b.(*CompositeBreaker); ok {
+		return c.calls
+	}
+	_, ok := b.(callBreaker)
+	return ok
+}
+
+// rejectsCalls reports whether the given breaker may reject calls (see [admissionBreaker]). Like for [observesCalls], a
+// [CompositeBreaker] only does if any of its breakers does.
+func rejectsCalls(b Breaker) bool {
+	if c, ok := b.(*CompositeBreaker); ok {
+		return c.admits
+	}
+	_, ok := b.(admissionBreaker)
+	return ok
+}
+
+// admissionOnly reports whether the given breaker only rejects calls but never opens the circuit.
+func admissionOnly(b Breaker) bool {
+	switch b := b.(type) {
+	case *AdaptiveThrottlingBreaker:
+		return true
+	case *CompositeBreaker:
+		return b.voters == 0
+	default:
+		return false
+	}
+}
+
+func (c *CompositeBreaker) observe(halfOpen, failure bool) StateChange {
+	for i, b := range c.breakers {
+		c.record(i, b.observe(halfOpen, failure))
+	}
+
+	return c.merge(halfOpen, failure)
+}
+
+func (c *CompositeBreaker) observeCall(halfOpen, failure bool, weight float64, duration time.Duration) StateChange {
+	for i, b := range c.breakers {
+		if cb, ok := b.(callBreaker); ok {
+			c.record(i, cb.observeCall(halfOpen, failure, weight, duration))
+		} else {
+			c.record(i, b.observe(halfOpen, failure))
+		}
+	}
+
+	return c.merge(halfOpen, failure)
+}
+
+func (c *CompositeBreaker) measuresDuration() bool {
+	for _, b := range c.breakers {
+		if cb, ok := b.(callBreaker); ok && cb.measuresDuration() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CompositeBreaker) admit() bool {
+	admit := true
+	for _, b := range c.breakers {
+		// Ask every breaker, so each one keeps track of all calls, even if an earlier one rejected it already.
+		if ab, ok := b.(admissionBreaker); ok && !ab.admit() {
+			admit = false
+		}
+	}
+	return admit
+}
+
+// record remembers the state the breaker at index i asked for, if any.
+func (c *CompositeBreaker) record(i int, sc StateChange) {
+	switch sc {
+	case StateChangeOpen:
+		c.open[i].Store(true)
+	case StateChangeClose:
+		c.open[i].Store(false)
+	}
+}
+
+// merge combines the last states of all breakers into a single state change.
+func (c *CompositeBreaker) merge(halfOpen, failure bool) StateChange {
+	if halfOpen && failure {
+		return StateChangeOpen
+	}
+
+	var openCount int
+	for i := range c.open {
+		if c.voting[i] && c.open[i].Load() {
+			openCount++
+		}
+	}
+
+	if (c.all && c.voters > 0 && openCount == c.voters) || (!c.all && openCount > 0) {
+		return StateChangeOpen
+	}
+	return StateChangeClose
+}
+
+// apply implements Option.
+func (c *CompositeBreaker) apply(o *options) error {
+	if len(c.breakers) == 0 {
+		return fmt.Errorf("CompositeBreaker requires at least one breaker")
+	}
+
+	for i, b := range c.breakers {
+		if b == nil {
+			return fmt.Errorf("CompositeBreaker breaker %d is nil", i)
+		}
+		if err := b.apply(o); err != nil {
+			return fmt.Errorf("CompositeBreaker breaker %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package hoglet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnyOf_opens_if_any_breaker_opens(t *testing.T) {
+	b := AnyOf(NewConsecutiveFailuresBreaker(1), NewConsecutiveFailuresBreaker(3))
+
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+	assert.Equal(t, StateChangeClose, b.observe(false, false), "expected close once all breakers closed")
+}
+
+func TestAllOf_opens_only_if_all_breakers_open(t *testing.T) {
+	b := AllOf(NewConsecutiveFailuresBreaker(1), NewConsecutiveFailuresBreaker(3))
+
+	assert.Equal(t, StateChangeClose, b.observe(false, true))
+	assert.Equal(t, StateChangeClose, b.observe(false, true))
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+	assert.Equal(t, StateChangeClose, b.observe(false, false))
+}
+
+func TestAllOf_leaves_out_admission_only_breakers(t *testing.T) {
+	b := AllOf(NewAdaptiveThrottlingBreaker(time.Minute, 2), NewConsecutiveFailuresBreaker(1))
+	assert.Equal(t, StateChangeOpen, b.observe(false, true))
+
+	b = AllOf(NewAdaptiveThrottlingBreaker(time.Minute, 2))
+	assert.Equal(t, StateChangeClose, b.observe(false, true), "expected no breaker to open the circuit")
+}
+
+func TestCompositeBreaker_only_implements_optional_interfaces_of_its_breakers(t *testing.T) {
+	c, err := NewCircuit(AnyOf(NewConsecutiveFailuresBreaker(1), NewConsecutiveFailuresBreaker(3)),
+		WithHalfOpenDelay(time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, c.callBreaker)
+	assert.Nil(t, c.admissionBreaker)
+
+	c, err = NewCircuit(AllOf(
+		NewAdaptiveThrottlingBreaker(time.Minute, 2),
+		AnyOf(NewSlowCallBreaker(time.Minute, time.Second, 0.4)),
+	))
+	require.NoError(t, err)
+	assert.NotNil(t, c.callBreaker)
+	assert.True(t, c.measureDuration)
+	assert.NotNil(t, c.admissionBreaker)
+}
+
+func TestAllOf_failed_probe_keeps_open(t *testing.T) {
+	b := AllOf(NewConsecutiveFailuresBreaker(1), NewSlidingWindowBreaker(time.Minute, 0.9))
+
+	assert.Equal(t, StateChangeOpen, b.observe(true, true))
+}
+
+func TestCompositeBreaker_forwards_durations(t *testing.T) {
+	b := AnyOf(NewConsecutiveFailuresBreaker(3), NewSlowCallBreaker(time.Minute, time.Second, 0.4))
+
+	assert.Equal(t, StateChangeClose, b.observeCall(false, false, 1, time.Millisecond))
+	assert.Equal(t, StateChangeOpen, b.observeCall(false, false, 1, 2*time.Second))
+	assert.Equal(t, StateChangeOpen, b.observeCall(false, false, 1, 2*time.Second))
+}
+
+func TestCompositeBreaker_applies_all_breakers(t *testing.T) {
+	_, err := NewCircuit(AnyOf(NewSlidingWindowBreaker(time.Minute, 0.1), NewEWMABreaker(10, 2)))
+	assert.Error(t, err, "expected the invalid EWMABreaker threshold to be rejected")
+
+	_, err = NewCircuit(AllOf(NewSlidingWindowBreaker(time.Minute, 0.1), nil))
+	assert.Error(t, err, "expected a nil breaker to be rejected")
+
+	_, err = NewCircuit(AnyOf())
+	assert.Error(t, err, "expected an empty composite to be rejected")
+
+	c, err := NewCircuit(AllOf(NewSlidingWindowBreaker(time.Minute, 0.1), NewConsecutiveFailuresBreaker(3)))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, c.halfOpenDelay, "expected the half-open delay default of the first breaker")
+}
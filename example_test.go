@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/exaring/hoglet"
@@ -136,3 +137,43 @@ func ExampleConcurrencyLimiter() {
 	// Output:
 	// hoglet: concurrency limit reached
 }
+
+// consecutiveTimeouts is a custom breaker opening after 3 consecutive failures.
+type consecutiveTimeouts struct {
+	streak atomic.Int64
+}
+
+func (c *consecutiveTimeouts) Observe(halfOpen, failure bool) hoglet.StateChange {
+	if !failure {
+		c.streak.Store(0)
+		return hoglet.StateChangeClose
+	}
+	if c.streak.Add(1) >= 3 || halfOpen {
+		return hoglet.StateChangeOpen
+	}
+	return hoglet.StateChangeNone
+}
+
+func ExampleBreakerFunc() {
+	h, err := hoglet.NewCircuit(
+		hoglet.BreakerFunc((&consecutiveTimeouts{}).Observe),
+		hoglet.WithHalfOpenDelay(time.Second),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for range 3 {
+		_, err = hoglet.Wrap(h, foo)(context.Background(), 100)
+		fmt.Println(err)
+	}
+
+	_, err = hoglet.Wrap(h, foo)(context.Background(), 1)
+	fmt.Println(err)
+
+	// Output:
+	// bar is too high!
+	// bar is too high!
+	// bar is too high!
+	// hoglet: breaker is open
+}
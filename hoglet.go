@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -19,6 +22,32 @@ type Circuit struct {
 	// State
 
 	openedAt atomic.Int64 // monotonic nanoseconds since start (see [nowNanos]); 0 = closed
+
+	// probes tracks the probes of the current half-open phase if their number is limited (see [WithHalfOpenProbes]).
+	probes probeSlots
+
+	// openDelay is the duration the circuit stays open before switching to half-open if it backs off (see
+	// [WithHalfOpenBackoff]), grown by reopens, the number of consecutive failed half-open phases.
+	openDelay atomic.Int64
+	reopens   atomic.Int64
+
+	// closedAt is set when the circuit closes if it starts slowly (see [WithSlowStart]); 0 outside of a slow start.
+	closedAt atomic.Int64 // monotonic nanoseconds since start (see [nowNanos])
+
+	// stopHealthProbe stops the background health probe, which closes healthProbeDone once it returned (see
+	// [WithHealthProbe]); both are nil without a health probe.
+	stopHealthProbe context.CancelFunc
+	healthProbeDone chan struct{}
+
+	// outageStart is when the circuit last opened while closed. With an adaptive half-open delay (see
+	// [WithAdaptiveHalfOpenDelay]), the time from there to closing again is added to recoveries, whose estimate is
+	// published as learnedDelay.
+	outageStart  atomic.Int64 // monotonic nanoseconds since start (see [nowNanos])
+	recoveries   recoveryTimes
+	learnedDelay atomic.Int64
+
+	// override is the manual override set by an operator (see [Circuit.Override]); nil if none was set yet.
+	override atomic.Pointer[overrideState]
 }
 
 // options is a sub-struct to avoid requiring type parameters in the [Option] type.
@@ -30,21 +59,112 @@ type options struct {
 	// limited (~1) amount of calls are allowed that - if successful - may re-close the breaker.
 	halfOpenDelay time.Duration
 
+	// halfOpenProbes is the number of concurrent probe calls admitted in the half-open state; 0 admits probes racily
+	// (~1) instead (see [WithHalfOpenProbes]).
+	halfOpenProbes int64
+
+	// halfOpenSuccesses is the number of successful probes required to close the circuit if probes are limited (see
+	// [WithHalfOpenSuccessThreshold]).
+	halfOpenSuccesses int64
+
+	// backoffFactor, backoffMax and backoffJitter grow the half-open delay on consecutive failed half-open phases (see
+	// [WithHalfOpenBackoff]); backoffFactor is 0 if the circuit does not back off.
+	backoffFactor float64
+	backoffMax    time.Duration
+	backoffJitter float64
+
+	// slowStart is the duration over which the circuit ramps up traffic after closing (see [WithSlowStart]); 0 if it
+	// admits all calls right away.
+	slowStart     time.Duration
+	slowStartRamp Ramp
+
+	// healthProbe is called every healthProbeInterval while the circuit is not closed (see [WithHealthProbe]); nil if
+	// the circuit only recovers via calls.
+	healthProbe         func(context.Context) error
+	healthProbeInterval time.Duration
+
+	// probeIneligibleByDefault is set if only calls marked via [WithProbeEligible] may act as half-open probes (see
+	// [WithProbeEligibleByDefault]).
+	probeIneligibleByDefault bool
+
+	// adaptiveMin and adaptiveMax bound the half-open delay learned from past recovery times (see
+	// [WithAdaptiveHalfOpenDelay]); adaptiveMax is 0 if the delay is not learned.
+	adaptiveMin time.Duration
+	adaptiveMax time.Duration
+
+	// minimumRequests is the number of calls a breaker has to observe in its current window or sample span before it
+	// may open the circuit.
+	minimumRequests int64
+
 	breaker         Breaker
 	observerFactory ObserverFactory
+
+	// failureWeight maps a failure's error to its weight (see [WithFailureWeight]); nil if failures are not weighted.
+	failureWeight func(error) float64
+
+	// callBreaker is the breaker as a [callBreaker], if it needs call details (see [observesCalls]); nil otherwise.
+	callBreaker callBreaker
+	// measureDuration is set if the breaker needs call durations (see [callBreaker]).
+	measureDuration bool
+	// admissionBreaker is the breaker as an [admissionBreaker], if it may reject calls (see [rejectsCalls]); nil
+	// otherwise.
+	admissionBreaker admissionBreaker
 }
 
 // Breaker is the interface implemented by the different breakers, responsible for actually opening the circuit.
 // Each implementation behaves differently when deciding whether to open the circuit upon failure.
+//
+// Breakers outside of this package can be implemented via [BreakerFunc].
 type Breaker interface {
 	// observe updates the breaker's state and returns whether the circuit should change state.
 	// The halfOpen parameter indicates whether the call was made in half-open state.
 	// The failure parameter indicates whether the call failed.
-	observe(halfOpen, failure bool) stateChange
+	observe(halfOpen, failure bool) StateChange
 
 	Option // breakers can also modify or sanity-check their circuit's options
 }
 
+// BreakerFunc is a helper to turn any function into a [Breaker], allowing custom breakers to be implemented outside of
+// this package. Stateful breakers can be implemented as a type and passed as a method value, e.g.
+// BreakerFunc(myBreaker.Observe).
+//
+// The function is called after each observed call with whether the call was made in half-open state and whether it
+// failed, and returns how the circuit should change its state. It may be called concurrently.
+//
+// Unlike the built-in breakers, a BreakerFunc does not validate the circuit's options. Note that a breaker that only
+// closes the circuit upon observing a success requires a half-open delay (see [WithHalfOpenDelay]), since an open
+// circuit does not observe any calls otherwise.
+type BreakerFunc func(halfOpen, failure bool) StateChange
+
+func (f BreakerFunc) observe(halfOpen, failure bool) StateChange {
+	return f(halfOpen, failure)
+}
+
+// apply implements Option.
+func (f BreakerFunc) apply(*options) error {
+	return nil
+}
+
+// callBreaker is implemented by breakers that take more details of a call into account than its outcome, like its
+// duration (e.g. [SlowCallBreaker]) or the weight of a failure (see [WithFailureWeight]). The circuit calls observeCall
+// instead of observe for such breakers.
+type callBreaker interface {
+	// observeCall is like observe, but additionally receives the weight of a failure (1 for successes and unweighted
+	// failures) and the duration of the call (0 unless measuresDuration returns true).
+	observeCall(halfOpen, failure bool, weight float64, duration time.Duration) StateChange
+
+	// measuresDuration reports whether the breaker needs call durations. The circuit only measures them if so, keeping
+	// the clock off the hot path otherwise.
+	measuresDuration() bool
+}
+
+// admissionBreaker is implemented by breakers that may reject individual calls while the circuit is closed (e.g.
+// [AdaptiveThrottlingBreaker]), instead of opening the whole circuit.
+type admissionBreaker interface {
+	// admit reports whether the call may go through. Rejected calls are not observed.
+	admit() bool
+}
+
 // ObserverFactory is an interface that allows customizing the per-call observer creation.
 type ObserverFactory interface {
 	// ObserverForCall returns an [Observer] for the incoming call.
@@ -107,15 +227,126 @@ func NewCircuit(breaker Breaker, opts ...Option) (*Circuit, error) {
 		}
 	}
 
+	if o.backoffFactor > 0 && o.backoffMax < o.halfOpenDelay {
+		return nil, fmt.Errorf("half-open backoff maximum (%s) cannot be less than the half-open delay (%s)", o.backoffMax, o.halfOpenDelay)
+	}
+	if o.backoffFactor > 0 {
+		if err := checkHalfOpenDelay(o, o.backoffMax); err != nil {
+			return nil, fmt.Errorf("applying half-open backoff maximum: %w", err)
+		}
+	}
+	if o.adaptiveMax > 0 && o.halfOpenDelay == 0 {
+		return nil, fmt.Errorf("adaptive half-open delay requires a half-open delay as initial estimate")
+	}
+	if o.adaptiveMax > 0 {
+		if err := checkHalfOpenDelay(o, o.adaptiveMax); err != nil {
+			return nil, fmt.Errorf("applying adaptive half-open delay maximum: %w", err)
+		}
+	}
+
+	if observesCalls(o.breaker) {
+		o.callBreaker = o.breaker.(callBreaker)
+		o.measureDuration = o.callBreaker.measuresDuration()
+	}
+	if rejectsCalls(o.breaker) {
+		o.admissionBreaker = o.breaker.(admissionBreaker)
+	}
+
+	if o.halfOpenSuccesses > 0 && o.halfOpenProbes == 0 {
+		o.halfOpenProbes = 1 // counting successes requires limiting probes
+	}
+	if o.halfOpenSuccesses == 0 {
+		o.halfOpenSuccesses = o.halfOpenProbes
+	}
+
 	c.options = o
+	c.learnedDelay.Store(int64(c.boundAdaptiveDelay(o.halfOpenDelay)))
+
+	if o.healthProbe != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.stopHealthProbe = cancel
+		c.healthProbeDone = make(chan struct{})
+		go c.runHealthProbe(ctx)
+	}
 
 	return c, nil
 }
 
-// State reports the current [State] of the [Circuit].
+// checkHalfOpenDelay checks a half-open delay the circuit may grow to (e.g. by backing off) against the breaker's
+// constraints on the half-open delay. Applying the breaker again only validates it, since it is applied with the same
+// options otherwise.
+func checkHalfOpenDelay(o options, delay time.Duration) error {
+	o.halfOpenDelay = delay
+	return o.breaker.apply(&o)
+}
+
+// Close stops the background health probe of the circuit (see [WithHealthProbe]) and waits for it to return. It is a
+// noop for circuits without a health probe and always returns nil.
+//
+// The circuit remains usable after closing, but only recovers via calls from then on.
+func (c *Circuit) Close() error {
+	if c.stopHealthProbe == nil {
+		return nil // noop
+	}
+	c.stopHealthProbe()
+	<-c.healthProbeDone
+	return nil
+}
+
+// runHealthProbe calls the health probe periodically while the circuit is not closed, until the given context is
+// canceled.
+func (c *Circuit) runHealthProbe(ctx context.Context) {
+	defer close(c.healthProbeDone)
+
+	ticker := time.NewTicker(c.healthProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.State() != StateClosed && c.currentOverride() == OverrideNone {
+				c.probeHealth(ctx)
+			}
+		}
+	}
+}
+
+// probeHealth calls the health probe once. A success is observed like a successful half-open probe call taking as long
+// as the probe, so it may close the circuit. A failure leaves the circuit as it is: it is no failed half-open phase, so
+// it neither restarts nor grows the half-open delay (see [WithHalfOpenBackoff]), and real calls may still probe the
+// circuit once half-open.
+func (c *Circuit) probeHealth(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, c.healthProbeInterval)
+	defer cancel()
+
+	start := nowNanos()
+	if c.failure(nil, c.healthProbe(probeCtx)) {
+		return
+	}
+
+	// The circuit itself is used instead of the observerFactory: the probe is not a call and must not show up in any
+	// middleware's metrics or limits.
+	so, err := c.stateObserverForCall(ctx, StateHalfOpen)
+	if err != nil {
+		return // all probe slots are taken by calls already
+	}
+	so.start = start // measure the probe, not the observer (e.g. for a [SlowCallBreaker])
+	so.Observe(false)
+}
+
+// State reports the current [State] of the [Circuit], taking manual overrides into account (see [Circuit.Override]).
 // It should only be used for informational purposes. To minimize race conditions, the circuit should be called directly
 // instead of checking its state first.
 func (c *Circuit) State() State {
+	switch c.currentOverride() {
+	case OverrideForceOpen:
+		return StateOpen
+	case OverrideForceClose, OverrideDisable:
+		return StateClosed
+	}
+
 	oa := c.openedAt.Load()
 
 	if oa == 0 {
@@ -123,7 +354,7 @@ func (c *Circuit) State() State {
 		return StateClosed
 	}
 
-	if c.halfOpenDelay == 0 || sinceNanos(oa) < c.halfOpenDelay {
+	if c.halfOpenDelay == 0 || sinceNanos(oa) < c.currentHalfOpenDelay() {
 		// open
 		return StateOpen
 	}
@@ -132,15 +363,21 @@ func (c *Circuit) State() State {
 	return StateHalfOpen
 }
 
-// stateForCall returns the state of the circuit meant for the next call.
+// stateForCall returns the state of the circuit meant for the call with the given context.
 // It wraps [State] to keep the mutable part outside of the external API.
-func (c *Circuit) stateForCall() State {
+func (c *Circuit) stateForCall(ctx context.Context) State {
 	state := c.State()
 
-	if state == StateHalfOpen {
+	if state == StateHalfOpen && !c.probeEligible(ctx) {
+		// ineligible calls must not act as probes, so for them the circuit is still open
+		return StateOpen
+	}
+
+	if state == StateHalfOpen && c.halfOpenProbes == 0 {
 		// We reset openedAt to block further calls to pass through when half-open. A success will cause the breaker to
 		// close. This is slightly racy: multiple goroutines may reach this point concurrently since we do not lock the
 		// breaker.
+		// With a limited number of probes, they are admitted strictly by [Circuit.ObserverForCall] instead.
 		c.reopen()
 	}
 
@@ -156,8 +393,13 @@ func (c *Circuit) open() {
 		return // noop
 	}
 
+	// The delay has to be set before the circuit opens, so [Circuit.State] never sees an unset one.
+	c.backOff(0)
+
 	// CompareAndSwap is needed to avoid clobbering another goroutine's openedAt value.
-	c.openedAt.CompareAndSwap(0, nowNanos())
+	if now := nowNanos(); c.openedAt.CompareAndSwap(0, now) {
+		c.outageStart.Store(now)
+	}
 }
 
 // reopen forcefully (re)marks the circuit as open, resetting the half-open time.
@@ -171,9 +413,182 @@ func (c *Circuit) close() {
 	if c.openedAt.Load() == 0 {
 		return // noop
 	}
+	c.learnRecovery()
+	c.reopens.Store(0)
+	if c.slowStart > 0 {
+		c.closedAt.Store(nowNanos())
+	}
 	c.openedAt.Store(0)
 }
 
+// reopenAfterProbe reopens the circuit after a failed probe of the half-open phase starting at openedAt, backing off if
+// configured (see [WithHalfOpenBackoff]). Only the first failed probe of a phase reopens the circuit; probes of a past
+// phase are ignored.
+func (c *Circuit) reopenAfterProbe(openedAt int64) {
+	if !c.openedAt.CompareAndSwap(openedAt, nowNanos()) {
+		return // the circuit reopened or closed in the meantime
+	}
+	// The previous delay is at most as long as the new one, so the circuit stays open until this is set.
+	c.backOff(c.reopens.Add(1))
+}
+
+// learnRecovery adds the time since the outage started to the recovery times the half-open delay is learned from, if
+// it is adaptive (see [WithAdaptiveHalfOpenDelay]). It must be called before resetting the reopens.
+func (c *Circuit) learnRecovery() {
+	if c.adaptiveMax == 0 {
+		return // noop
+	}
+	recovery := sinceNanos(c.outageStart.Load())
+	if c.reopens.Load() == 0 {
+		// The first probe succeeded, so the dependency may have recovered any time before. Halving the sample lets the
+		// estimate shrink, since a recovery time can never be observed shorter than the delay it was probed after.
+		recovery /= 2
+	}
+	c.learnedDelay.Store(int64(c.boundAdaptiveDelay(c.recoveries.add(recovery))))
+}
+
+// boundAdaptiveDelay bounds the given delay by the adaptive half-open delay's minimum and maximum.
+func (c *Circuit) boundAdaptiveDelay(delay time.Duration) time.Duration {
+	if c.adaptiveMax == 0 {
+		return delay
+	}
+	return min(max(delay, c.adaptiveMin), c.adaptiveMax)
+}
+
+// baseHalfOpenDelay returns the half-open delay before backing off: the learned one if it is adaptive (see
+// [WithAdaptiveHalfOpenDelay]), the configured one otherwise.
+func (c *Circuit) baseHalfOpenDelay() time.Duration {
+	if c.adaptiveMax == 0 {
+		return c.halfOpenDelay
+	}
+	return time.Duration(c.learnedDelay.Load())
+}
+
+// backOff sets the half-open delay for the given number of consecutive failed half-open phases: the base delay,
+// multiplied by the backoff factor once per reopen, capped and jittered.
+func (c *Circuit) backOff(reopens int64) {
+	if c.backoffFactor == 0 {
+		return // noop
+	}
+	delay := min(float64(c.baseHalfOpenDelay())*math.Pow(c.backoffFactor, float64(reopens)), float64(c.backoffMax))
+	delay -= delay * c.backoffJitter * rand.Float64()
+	c.openDelay.Store(int64(delay))
+}
+
+// admitSlowStart reports whether a call may go through while the circuit ramps up traffic after closing (see
+// [WithSlowStart]).
+func (c *Circuit) admitSlowStart() bool {
+	ca := c.closedAt.Load()
+	if ca == 0 {
+		return true
+	}
+	elapsed := sinceNanos(ca)
+	if elapsed >= c.slowStart {
+		c.closedAt.CompareAndSwap(ca, 0) // keep the clock off the hot path again
+		return true
+	}
+	return rand.Float64() < c.slowStartRamp.fraction(elapsed, c.slowStart)
+}
+
+// currentHalfOpenDelay returns the duration the circuit stays open before switching to half-open.
+func (c *Circuit) currentHalfOpenDelay() time.Duration {
+	if c.backoffFactor == 0 {
+		return c.baseHalfOpenDelay()
+	}
+	return time.Duration(c.openDelay.Load())
+}
+
+// probeSlots tracks the probes admitted in a half-open phase if their number is limited (see [WithHalfOpenProbes]).
+// A phase is identified by the circuit's openedAt, so reopening or closing the circuit implicitly starts a new one.
+//
+// It is locked, since it is only used while the circuit is half-open; the counts have to be consistent with the phase
+// they belong to, which a phase change racing admissions would break otherwise.
+type probeSlots struct {
+	mu        sync.Mutex
+	phase     int64   // openedAt of the half-open phase the counts belong to
+	inFlight  []int64 // monotonic nanoseconds since start (see [nowNanos]) each probe in flight was admitted at
+	successes int64
+}
+
+// acquireProbe admits a probe call in the half-open state if a probe slot is free, returning the opening time of the
+// half-open phase it was admitted in and the time it was admitted at.
+//
+// Slots are only freed by observing their probes. If all of them are taken and the oldest one has been held for longer
+// than the half-open delay (e.g. by a call hanging without a deadline), a new half-open phase is started, so the
+// circuit cannot get stuck.
+func (c *Circuit) acquireProbe() (openedAt, admittedAt int64, ok bool) {
+	c.probes.mu.Lock()
+	defer c.probes.mu.Unlock()
+
+	openedAt = c.openedAt.Load()
+	if openedAt == 0 {
+		return 0, 0, false // closed in the meantime
+	}
+	if c.probes.phase != openedAt {
+		c.probes.startPhase(openedAt)
+	}
+
+	if int64(len(c.probes.inFlight)) >= c.halfOpenProbes {
+		delay := c.currentHalfOpenDelay()
+		if sinceNanos(c.probes.oldest()) < delay {
+			return 0, 0, false
+		}
+		// Backdate the new phase by the delay, so the circuit stays half-open. Probes of the stuck phase are ignored.
+		if !c.openedAt.CompareAndSwap(openedAt, nowNanos()-int64(delay)) {
+			return 0, 0, false // reopened or closed in the meantime
+		}
+		openedAt = c.openedAt.Load()
+		c.probes.startPhase(openedAt)
+	}
+
+	admittedAt = nowNanos()
+	c.probes.inFlight = append(c.probes.inFlight, admittedAt)
+
+	return openedAt, admittedAt, true
+}
+
+// startPhase resets the counts for the half-open phase opened at the given time. The lock must be held.
+func (p *probeSlots) startPhase(openedAt int64) {
+	p.phase = openedAt
+	p.inFlight = p.inFlight[:0]
+	p.successes = 0
+}
+
+// oldest returns the admission time of the oldest probe in flight. The lock must be held.
+func (p *probeSlots) oldest() int64 {
+	return slices.Min(p.inFlight)
+}
+
+// release frees the slot of the probe admitted at the given time. The lock must be held.
+func (p *probeSlots) release(admittedAt int64) {
+	if i := slices.Index(p.inFlight, admittedAt); i >= 0 {
+		p.inFlight = slices.Delete(p.inFlight, i, i+1)
+	}
+}
+
+// observeProbe evaluates the outcome of a probe admitted by [Circuit.acquireProbe]: a failure reopens the circuit,
+// while it closes once enough probes succeeded (see [WithHalfOpenSuccessThreshold]). Probes of a past half-open phase
+// are ignored.
+func (c *Circuit) observeProbe(openedAt, admittedAt int64, failure bool) {
+	if failure {
+		c.reopenAfterProbe(openedAt)
+		return
+	}
+
+	c.probes.mu.Lock()
+	defer c.probes.mu.Unlock()
+
+	if c.probes.phase != openedAt || c.openedAt.Load() != openedAt {
+		return // the circuit reopened or closed in the meantime
+	}
+	c.probes.successes++
+	if c.probes.successes >= c.halfOpenSuccesses {
+		c.close()
+		return
+	}
+	c.probes.release(admittedAt) // free the slot for the next probe
+}
+
 // start is captured once at package load so the circuit and breakers can measure elapsed time using the monotonic
 // clock, making time-based state transitions immune to wall-clock jumps (e.g. NTP steps). Timestamps derived from it
 // (e.g. [Circuit.openedAt]) are stored as monotonic nanoseconds since start and are not wall-clock meaningful.
@@ -198,32 +613,107 @@ func sinceNanos(nanos int64) time.Duration {
 
 // ObserverForCall returns an [Observer] for the incoming call.
 // It is called exactly once per call to [Circuit.Call], before calling the wrapped function.
-// If the breaker is open, it returns [ErrCircuitOpen] as an error and a nil [Observer].
-// If the breaker is closed, it returns a non-nil [Observer] that will be used to observe the result of the call.
+// It returns [ErrCircuitOpen] as an error and a nil [Observer] if:
+//   - the circuit is open,
+//   - the breaker rejects the call (see [AdaptiveThrottlingBreaker]),
+//   - all half-open probe slots are taken (see [WithHalfOpenProbes]), or
+//   - the call exceeds the admitted share of a slow start (see [WithSlowStart]).
+//
+// Otherwise, it returns a non-nil [Observer] that will be used to observe the result of the call.
 //
 // It implements [ObserverFactory], so that the [Circuit] can act as the base for [BreakerMiddleware].
-func (c *Circuit) ObserverForCall(_ context.Context, state State) (Observer, error) {
+func (c *Circuit) ObserverForCall(ctx context.Context, state State) (Observer, error) {
+	so, err := c.stateObserverForCall(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return so, nil
+}
+
+// stateObserverForCall implements [Circuit.ObserverForCall], returning the concrete observer.
+func (c *Circuit) stateObserverForCall(ctx context.Context, state State) (stateObserver, error) {
 	if state == StateOpen {
-		return nil, ErrCircuitOpen
+		return stateObserver{}, ErrCircuitOpen
+	}
+	if state == StateClosed && (c.slowStart > 0 || c.admissionBreaker != nil) && c.currentOverride() == OverrideNone {
+		if c.slowStart > 0 && !c.admitSlowStart() {
+			return stateObserver{}, ErrCircuitOpen
+		}
+		if c.admissionBreaker != nil && !c.admissionBreaker.admit() {
+			return stateObserver{}, ErrCircuitOpen
+		}
 	}
-	return stateObserver{
+	so := stateObserver{
 		circuit: c,
 		state:   state,
-	}, nil
+	}
+	if state == StateHalfOpen && c.halfOpenProbes > 0 {
+		// Admitting probes here rather than in [Circuit.stateForCall] guarantees the slot is freed by the observer, even
+		// if a middleware rejects the call before reaching the circuit.
+		var ok bool
+		if so.openedAt, so.admittedAt, ok = c.acquireProbe(); !ok {
+			return stateObserver{}, ErrCircuitOpen
+		}
+	} else if state == StateHalfOpen {
+		so.openedAt = c.openedAt.Load() // reset by [Circuit.stateForCall] already
+	}
+	if c.measureDuration {
+		so.start = nowNanos()
+	}
+	if c.failureWeight != nil {
+		// set by [Wrap]; may be missing if the circuit is used as an [ObserverFactory] directly
+		so.weight, _ = ctx.Value(callWeightKey{}).(*callWeight)
+	}
+	return so, nil
 }
 
 type stateObserver struct {
 	circuit *Circuit
 	state   State
+	start   int64       // monotonic nanoseconds since start (see [nowNanos]); only set if measureDuration is set
+	weight  *callWeight // only set if failureWeight is set
+
+	// openedAt identifies the half-open phase a probe was admitted in and admittedAt its slot (see
+	// [Circuit.acquireProbe]).
+	openedAt   int64
+	admittedAt int64
 }
 
 func (s stateObserver) Observe(failure bool) {
-	switch s.circuit.breaker.observe(s.state == StateHalfOpen, failure) {
-	case stateChangeNone:
+	var sc StateChange
+	if s.circuit.callBreaker != nil {
+		weight := 1.0
+		if failure && s.weight != nil {
+			weight = s.weight.weight
+		}
+		var duration time.Duration
+		if s.circuit.measureDuration {
+			duration = sinceNanos(s.start)
+		}
+		sc = s.circuit.callBreaker.observeCall(s.state == StateHalfOpen, failure, weight, duration)
+	} else {
+		sc = s.circuit.breaker.observe(s.state == StateHalfOpen, failure)
+	}
+
+	if s.circuit.currentOverride() != OverrideNone {
+		return // the breaker keeps observing, but the circuit's state is overridden manually
+	}
+
+	if s.state == StateHalfOpen && s.circuit.halfOpenProbes > 0 {
+		s.circuit.observeProbe(s.openedAt, s.admittedAt, failure || sc == StateChangeOpen)
+		return
+	}
+
+	switch sc {
+	case StateChangeNone:
 		return // noop
-	case stateChangeOpen:
+	case StateChangeOpen:
+		if s.state == StateHalfOpen {
+			s.circuit.reopenAfterProbe(s.openedAt)
+			return
+		}
 		s.circuit.open()
-	case stateChangeClose:
+	case StateChangeClose:
 		s.circuit.close()
 	}
 }
@@ -240,9 +730,24 @@ func (s stateObserver) Observe(failure bool) {
 // [WithFailureCondition] and [IgnoreContextCanceled] on the provided [Circuit].
 //
 // Panics are observed as failures, but are not recovered (i.e.: they are "repanicked" instead).
+//
+// Manual overrides of the circuit (see [Circuit.Override]) are honored on each call.
 func Wrap[IN, OUT any](c *Circuit, f WrappableFunc[IN, OUT]) WrappableFunc[IN, OUT] {
 	return func(ctx context.Context, in IN) (out OUT, err error) {
-		obs, err := c.observerFactory.ObserverForCall(ctx, c.stateForCall())
+		if c.currentOverride() == OverrideDisable {
+			return f(ctx, in)
+		}
+
+		factoryCtx := ctx
+		var cw *callWeight
+		if c.failureWeight != nil {
+			// The weight has to reach the circuit's own observer past any middleware, so it is passed via the context.
+			// This costs an allocation per call, which is why it is only done if failures are weighted.
+			cw = &callWeight{weight: 1}
+			factoryCtx = context.WithValue(ctx, callWeightKey{}, cw)
+		}
+
+		obs, err := c.observerFactory.ObserverForCall(factoryCtx, c.stateForCall(ctx))
 		if err != nil {
 			// Note: any errors here are not "observed" and do not count towards the breaker's failure rate.
 			// This includes:
@@ -270,16 +775,16 @@ func Wrap[IN, OUT any](c *Circuit, f WrappableFunc[IN, OUT]) WrappableFunc[IN, O
 			obsCtx, cancel := context.WithCancelCause(ctx)
 			defer cancel(errWrappedFunctionDone)
 
-			go c.observeCtx(obs, obsCtx)
+			go c.observeCtx(obs, cw, obsCtx)
 		}
 
 		defer func() {
 			// ensure we also open the breaker on panics
 			if err := recover(); err != nil {
-				obs.Observe(true)
+				obs.Observe(cw.resolve(func() (bool, float64) { return true, 1 }))
 				panic(err) // let the caller deal with panics
 			}
-			obs.Observe(err != nil && c.options.isFailure(err))
+			obs.Observe(c.failure(cw, err))
 		}()
 
 		return f(ctx, in)
@@ -291,7 +796,7 @@ var errWrappedFunctionDone = errors.New("wrapped function done")
 
 // observeCtx observes the given context for cancellation and records it as a failure.
 // It assumes [Observer] is idempotent and deduplicates calls itself.
-func (c *Circuit) observeCtx(obs Observer, ctx context.Context) {
+func (c *Circuit) observeCtx(obs Observer, cw *callWeight, ctx context.Context) {
 	// We want to observe a context error as soon as possible to open the breaker, but at the same time we want to
 	// keep the call to the wrapped function synchronous to avoid all pitfalls that come with asynchronicity.
 	<-ctx.Done()
@@ -300,7 +805,65 @@ func (c *Circuit) observeCtx(obs Observer, ctx context.Context) {
 	if context.Cause(ctx) == errWrappedFunctionDone {
 		err = nil // ignore internal cancellations; the wrapped function returned already
 	}
-	obs.Observe(err != nil && c.options.isFailure(err))
+	obs.Observe(c.failure(cw, err))
+}
+
+// failure reports whether the given error of a call is considered a failure, resolving the call's weight if any.
+func (c *Circuit) failure(cw *callWeight, err error) bool {
+	if cw == nil {
+		return err != nil && c.options.isFailure(err)
+	}
+	return cw.resolve(func() (bool, float64) {
+		if err == nil || !c.options.isFailure(err) {
+			return false, 1
+		}
+		return true, max(0, c.options.failureWeight(err))
+	})
+}
+
+// probeEligibleKey is the context key for marking calls as (in)eligible half-open probes (see [WithProbeEligible]).
+type probeEligibleKey struct{}
+
+// WithProbeEligible returns a copy of the given context marking calls made with it as eligible to act as half-open
+// probes or not. Ineligible calls, like non-idempotent writes, keep getting [ErrCircuitOpen] while the circuit is
+// half-open and only go through once it closed.
+//
+// Unmarked calls are eligible unless set otherwise via [WithProbeEligibleByDefault].
+func WithProbeEligible(ctx context.Context, eligible bool) context.Context {
+	return context.WithValue(ctx, probeEligibleKey{}, eligible)
+}
+
+// probeEligible reports whether a call with the given context may act as a half-open probe.
+func (c *Circuit) probeEligible(ctx context.Context) bool {
+	if eligible, ok := ctx.Value(probeEligibleKey{}).(bool); ok {
+		return eligible
+	}
+	return !c.probeIneligibleByDefault
+}
+
+// callWeight carries the weight of a call's failure from [Wrap] to the circuit's [Observer] (see [WithFailureWeight]).
+type callWeight struct {
+	once    sync.Once
+	failure bool
+	weight  float64
+}
+
+// callWeightKey is the context key for a call's [callWeight].
+type callWeightKey struct{}
+
+// resolve sets the call's outcome and weight via the given function and returns whether it failed. Only the first
+// resolution counts: since the context watchdog may race the wrapped function's return, all observations of a call
+// report the same outcome, matching the weight seen by the observer.
+// A nil callWeight always resolves via the given function.
+func (cw *callWeight) resolve(f func() (failure bool, weight float64)) bool {
+	if cw == nil {
+		failure, _ := f()
+		return failure
+	}
+	cw.once.Do(func() {
+		cw.failure, cw.weight = f()
+	})
+	return cw.failure
 }
 
 // State represents the state of a circuit.
@@ -309,7 +872,7 @@ type State int
 const (
 	// StateClosed means a circuit is ready to accept calls.
 	StateClosed State = iota
-	// StateHalfOpen means a limited (~1) number of calls is allowed through.
+	// StateHalfOpen means a limited number of calls is allowed through (~1 by default, see [WithHalfOpenProbes]).
 	StateHalfOpen
 	// StateOpen means a circuit is not accepting calls.
 	StateOpen
@@ -328,6 +891,40 @@ func (s State) String() string {
 	}
 }
 
+// Ramp determines how the share of admitted calls grows during a slow start (see [WithSlowStart]).
+type Ramp int
+
+const (
+	// RampLinear admits a share of calls growing linearly from 0 to 1 over the slow start.
+	RampLinear Ramp = iota
+	// RampExponential admits a share of calls growing exponentially from 1% to 100% over the slow start, doubling at a
+	// constant rate. It is more careful than [RampLinear] early on and catches up quickly towards the end.
+	RampExponential
+)
+
+func (r Ramp) String() string {
+	switch r {
+	case RampLinear:
+		return "linear"
+	case RampExponential:
+		return "exponential"
+	default:
+		return "unknown"
+	}
+}
+
+// rampExponentialStart is the share of calls [RampExponential] starts with.
+const rampExponentialStart = 0.01
+
+// fraction returns the share of calls to admit after the given time elapsed of a slow start of the given duration.
+func (r Ramp) fraction(elapsed, duration time.Duration) float64 {
+	progress := float64(elapsed) / float64(duration)
+	if r == RampExponential {
+		return math.Pow(rampExponentialStart, 1-progress)
+	}
+	return progress
+}
+
 // defaultFailureCondition is the default failure condition used by [NewCircuit].
 // It considers any non-nil error a failure.
 func defaultFailureCondition(err error) bool {
@@ -336,8 +933,8 @@ func defaultFailureCondition(err error) bool {
 
 type noopBreaker struct{}
 
-func (noopBreaker) observe(halfOpen, failure bool) stateChange {
-	return stateChangeNone
+func (noopBreaker) observe(halfOpen, failure bool) StateChange {
+	return StateChangeNone
 }
 
 func (noopBreaker) apply(*options) error {
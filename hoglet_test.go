@@ -3,7 +3,10 @@ package hoglet
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/synctest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -118,11 +121,11 @@ func TestCircuit_ignored_context_cancellation_still_returned(t *testing.T) {
 type mockBreaker struct{}
 
 // observer implements [Breaker]
-func (mt *mockBreaker) observe(halfOpen, failure bool) stateChange {
+func (mt *mockBreaker) observe(halfOpen, failure bool) StateChange {
 	if failure {
-		return stateChangeOpen
+		return StateChangeOpen
 	}
-	return stateChangeClose
+	return StateChangeClose
 }
 
 func (mt *mockBreaker) apply(o *options) error {
@@ -223,6 +226,485 @@ func TestCircuit_failure_condition_never_called_with_nil_error(t *testing.T) {
 	assert.False(t, conditionCalled, "failure condition should not have been called for a successful call")
 }
 
+func TestCircuit_observes_call_duration(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		slow := func(_ context.Context, d time.Duration) (struct{}, error) {
+			time.Sleep(d)
+			return struct{}{}, nil
+		}
+
+		b, err := NewCircuit(NewSlowCallBreaker(time.Minute, time.Second, 0.4))
+		require.NoError(t, err)
+
+		_, err = Wrap(b, slow)(t.Context(), time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, StateClosed, b.State(), "fast call should not open the circuit")
+
+		_, err = Wrap(b, slow)(t.Context(), 2*time.Second)
+		require.NoError(t, err, "slow calls still return their result")
+		assert.Equal(t, StateOpen, b.State(), "slow call should open the circuit")
+	})
+}
+
+func TestCircuit_admission_breaker_rejects_without_opening(t *testing.T) {
+	b, err := NewCircuit(NewAdaptiveThrottlingBreaker(time.Minute, 1))
+	require.NoError(t, err)
+
+	var rejected int
+	for range 100 {
+		_, err := Wrap(b, noop)(t.Context(), noopInFailure)
+		if err == ErrCircuitOpen {
+			rejected++
+		}
+	}
+
+	assert.Positive(t, rejected, "expected some calls to be throttled")
+	assert.Less(t, rejected, 100, "expected some calls to go through")
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestCircuit_weighs_failures(t *testing.T) {
+	errCheap := errors.New("cheap")
+	errExpensive := errors.New("expensive")
+	fail := func(_ context.Context, err error) (struct{}, error) { return struct{}{}, err }
+
+	b, err := NewCircuit(
+		NewSlidingWindowBreaker(time.Minute, 0.5),
+		WithFailureWeight(func(err error) float64 {
+			if err == errExpensive {
+				return 3
+			}
+			return 0.5
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = Wrap(b, fail)(t.Context(), nil)
+	require.NoError(t, err)
+	_, err = Wrap(b, fail)(t.Context(), errCheap)
+	require.ErrorIs(t, err, errCheap)
+	assert.Equal(t, StateClosed, b.State(), "cheap failure should not open the circuit")
+
+	_, err = Wrap(b, fail)(t.Context(), errExpensive)
+	require.ErrorIs(t, err, errExpensive)
+	assert.Equal(t, StateOpen, b.State(), "expensive failure should open the circuit")
+}
+
+func TestCircuit_half_open_probes_admitted_strictly(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		release := make(chan struct{})
+		blocking := func(context.Context, noopIn) (struct{}, error) {
+			<-release
+			return struct{}{}, nil
+		}
+
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithHalfOpenProbes(2))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(time.Minute)
+		require.Equal(t, StateHalfOpen, c.State())
+
+		for range 2 {
+			go func() {
+				_, err := Wrap(c, blocking)(context.Background(), noopInSuccess)
+				assert.NoError(t, err)
+			}()
+		}
+		synctest.Wait()
+
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		assert.ErrorIs(t, err, ErrCircuitOpen, "all probe slots should be taken")
+		assert.Equal(t, StateHalfOpen, c.State())
+
+		close(release)
+		synctest.Wait()
+		assert.Equal(t, StateClosed, c.State(), "circuit should close once all probes succeeded")
+	})
+}
+
+func TestCircuit_half_open_probes_do_not_get_stuck(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		release := make(chan struct{})
+		hanging := func(context.Context, noopIn) (struct{}, error) {
+			<-release
+			return struct{}{}, errSentinel
+		}
+
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithHalfOpenProbes(1))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(time.Minute)
+
+		go func() {
+			_, _ = Wrap(c, hanging)(context.Background(), noopInSuccess)
+		}()
+		synctest.Wait()
+
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		require.ErrorIs(t, err, ErrCircuitOpen, "the probe slot should be taken")
+
+		time.Sleep(time.Minute)
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		require.NoError(t, err, "a new half-open phase should have started")
+		assert.Equal(t, StateClosed, c.State())
+
+		close(release)
+		synctest.Wait()
+		assert.Equal(t, StateClosed, c.State(), "the stuck probe must not affect the circuit anymore")
+	})
+}
+
+func TestCircuit_half_open_probes_outlasting_the_delay_close(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		slow := func(context.Context, noopIn) (struct{}, error) {
+			time.Sleep(30 * time.Millisecond)
+			return struct{}{}, nil
+		}
+
+		c, err := NewCircuit(NewConsecutiveFailuresBreaker(1), WithHalfOpenDelay(100*time.Millisecond),
+			WithHalfOpenSuccessThreshold(5))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(100 * time.Millisecond)
+
+		// The sequential probes take longer than the half-open delay in total, which must not restart the phase.
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Go(func() {
+				for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); {
+					if _, err := Wrap(c, slow)(context.Background(), noopInSuccess); errors.Is(err, ErrCircuitOpen) {
+						time.Sleep(10 * time.Millisecond)
+					}
+				}
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, StateClosed, c.State())
+	})
+}
+
+func TestCircuit_half_open_probe_failure_reopens(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithHalfOpenProbes(2))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(time.Minute)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		require.NoError(t, err)
+		assert.Equal(t, StateHalfOpen, c.State(), "a single success should not close the circuit")
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		assert.Equal(t, StateOpen, c.State(), "a failed probe should reopen the circuit")
+
+		time.Sleep(time.Minute)
+		for range 2 {
+			_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, StateClosed, c.State())
+	})
+}
+
+func TestCircuit_half_open_success_threshold(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithHalfOpenSuccessThreshold(3))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(time.Minute)
+
+		for i := range 3 {
+			assert.Equal(t, StateHalfOpen, c.State(), "circuit should stay half-open after %d successes", i)
+			_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, StateClosed, c.State(), "circuit should close after 3 successes")
+	})
+}
+
+func TestCircuit_half_open_backoff(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithHalfOpenBackoff(2, 5*time.Minute, 0))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+
+		// each failed probe doubles the delay, up to the maximum
+		for _, delay := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute} {
+			time.Sleep(delay - time.Second)
+			assert.Equal(t, StateOpen, c.State(), "circuit should stay open for %s", delay)
+			time.Sleep(time.Second)
+			assert.Equal(t, StateHalfOpen, c.State(), "circuit should be half-open after %s", delay)
+
+			_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+			require.ErrorIs(t, err, errSentinel)
+		}
+
+		// closing resets the delay
+		time.Sleep(5 * time.Minute)
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		require.NoError(t, err)
+		require.Equal(t, StateClosed, c.State())
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(time.Minute)
+		assert.Equal(t, StateHalfOpen, c.State())
+	})
+}
+
+func TestCircuit_half_open_backoff_jitter(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithHalfOpenBackoff(2, time.Hour, 0.5))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+
+		delay := c.currentHalfOpenDelay()
+		assert.GreaterOrEqual(t, delay, 30*time.Second)
+		assert.LessOrEqual(t, delay, time.Minute)
+	})
+}
+
+func TestCircuit_slow_start(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute), WithSlowStart(time.Minute, RampLinear))
+		require.NoError(t, err)
+
+		admitted := func() (n int) {
+			for range 1000 {
+				if _, err := Wrap(c, noop)(context.Background(), noopInSuccess); err == nil {
+					n++
+				}
+			}
+			return n
+		}
+
+		assert.Equal(t, 1000, admitted(), "a new circuit should not start slowly")
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(time.Minute)
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		require.NoError(t, err)
+		require.Equal(t, StateClosed, c.State())
+
+		time.Sleep(15 * time.Second)
+		assert.InDelta(t, 250, admitted(), 100, "a quarter of the calls should be admitted")
+
+		time.Sleep(45 * time.Second)
+		assert.Equal(t, 1000, admitted(), "all calls should be admitted after the slow start")
+	})
+}
+
+func TestRamp_fraction(t *testing.T) {
+	assert.InDelta(t, 0.0, RampLinear.fraction(0, time.Minute), 1e-9)
+	assert.InDelta(t, 0.5, RampLinear.fraction(30*time.Second, time.Minute), 1e-9)
+	assert.InDelta(t, 0.01, RampExponential.fraction(0, time.Minute), 1e-9)
+	assert.InDelta(t, 0.1, RampExponential.fraction(30*time.Second, time.Minute), 1e-9)
+	assert.InDelta(t, 1.0, RampExponential.fraction(time.Minute, time.Minute), 1e-9)
+}
+
+func TestCircuit_health_probe(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var healthy atomic.Bool
+		probe := func(context.Context) error {
+			if !healthy.Load() {
+				return errSentinel
+			}
+			return nil
+		}
+
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Hour), WithHealthProbe(probe, time.Second))
+		require.NoError(t, err)
+		defer c.Close()
+
+		time.Sleep(10 * time.Second)
+		assert.Equal(t, StateClosed, c.State(), "a failing probe must not open a closed circuit")
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+
+		time.Sleep(10 * time.Second)
+		assert.Equal(t, StateOpen, c.State(), "failing probes should keep the circuit open")
+
+		healthy.Store(true)
+		time.Sleep(time.Second)
+		synctest.Wait() // let the probe run, which is due at the same time
+		assert.Equal(t, StateClosed, c.State(), "a successful probe should close the circuit")
+	})
+}
+
+func TestCircuit_failed_health_probe_is_no_failed_half_open_phase(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		probe := func(context.Context) error { return errSentinel }
+
+		c, err := NewCircuit(
+			&mockBreaker{},
+			WithHalfOpenDelay(time.Minute),
+			WithHalfOpenBackoff(2, time.Hour, 0),
+			WithHealthProbe(probe, time.Second),
+		)
+		require.NoError(t, err)
+		defer c.Close()
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+
+		time.Sleep(time.Minute)
+		synctest.Wait()
+		assert.Equal(t, StateHalfOpen, c.State(), "failed probes must neither restart nor grow the half-open delay")
+		assert.Equal(t, time.Minute, c.currentHalfOpenDelay())
+
+		_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+		require.NoError(t, err, "real calls should still probe the circuit")
+		assert.Equal(t, StateClosed, c.State())
+	})
+}
+
+func TestCircuit_slow_health_probe_does_not_close(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		slow := func(context.Context, noopIn) (struct{}, error) {
+			time.Sleep(5 * time.Second)
+			return struct{}{}, nil
+		}
+
+		c, err := NewCircuit(
+			NewSlowCallBreaker(time.Minute, time.Second, 0.1),
+			WithHealthProbe(func(ctx context.Context) error {
+				_, err := slow(ctx, noopInSuccess)
+				return err
+			}, 10*time.Second),
+		)
+		require.NoError(t, err)
+		defer c.Close()
+
+		_, err = Wrap(c, slow)(context.Background(), noopInSuccess)
+		require.NoError(t, err)
+		require.Equal(t, StateOpen, c.State())
+
+		time.Sleep(time.Minute)
+		synctest.Wait()
+		assert.NotEqual(t, StateClosed, c.State(), "a slow probe must not close the circuit")
+	})
+}
+
+func TestCircuit_Close_stops_health_probe(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var probes atomic.Int64
+		probe := func(context.Context) error {
+			probes.Add(1)
+			return errSentinel
+		}
+
+		c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Hour), WithHealthProbe(probe, time.Second))
+		require.NoError(t, err)
+
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		require.ErrorIs(t, err, errSentinel)
+		time.Sleep(3 * time.Second)
+		synctest.Wait()
+		require.NoError(t, c.Close())
+		require.NoError(t, c.Close(), "closing twice should be a noop")
+
+		n := probes.Load()
+		assert.Equal(t, int64(3), n)
+		time.Sleep(3 * time.Second)
+		assert.Equal(t, n, probes.Load(), "no probes expected after closing")
+	})
+}
+
+func TestCircuit_probe_eligibility(t *testing.T) {
+	for name, tt := range map[string]struct {
+		opts       []Option
+		ineligible context.Context
+		eligible   context.Context
+	}{
+		"eligible by default": {
+			ineligible: WithProbeEligible(context.Background(), false),
+			eligible:   context.Background(),
+		},
+		"ineligible by default": {
+			opts:       []Option{WithProbeEligibleByDefault(false)},
+			ineligible: context.Background(),
+			eligible:   WithProbeEligible(context.Background(), true),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			synctest.Test(t, func(t *testing.T) {
+				c, err := NewCircuit(&mockBreaker{}, append(tt.opts, WithHalfOpenDelay(time.Minute))...)
+				require.NoError(t, err)
+
+				_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+				require.ErrorIs(t, err, errSentinel)
+				time.Sleep(time.Minute)
+
+				_, err = Wrap(c, noop)(tt.ineligible, noopInSuccess)
+				assert.ErrorIs(t, err, ErrCircuitOpen, "ineligible call must not probe")
+				assert.Equal(t, StateHalfOpen, c.State())
+
+				_, err = Wrap(c, noop)(tt.eligible, noopInSuccess)
+				require.NoError(t, err)
+				assert.Equal(t, StateClosed, c.State())
+
+				_, err = Wrap(c, noop)(tt.ineligible, noopInSuccess)
+				assert.NoError(t, err, "ineligible calls should go through once closed")
+			})
+		})
+	}
+}
+
+func TestCircuit_adaptive_half_open_delay(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		c, err := NewCircuit(
+			&mockBreaker{},
+			WithHalfOpenDelay(time.Minute),
+			WithAdaptiveHalfOpenDelay(10*time.Second, 5*time.Minute),
+		)
+		require.NoError(t, err)
+
+		// outage opens the circuit and asserts it is half-open after the given delay, failing the given number of probes
+		// first
+		outage := func(delay time.Duration, failures int) {
+			t.Helper()
+			_, err := Wrap(c, noop)(context.Background(), noopInFailure)
+			require.ErrorIs(t, err, errSentinel)
+			for i := range failures + 1 {
+				time.Sleep(delay - time.Second)
+				require.Equal(t, StateOpen, c.State(), "circuit should stay open for %s", delay)
+				time.Sleep(time.Second)
+				require.Equal(t, StateHalfOpen, c.State(), "circuit should be half-open after %s", delay)
+
+				arg := noopInFailure
+				if i == failures {
+					arg = noopInSuccess
+				}
+				_, _ = Wrap(c, noop)(context.Background(), arg)
+			}
+			require.Equal(t, StateClosed, c.State())
+		}
+
+		outage(time.Minute, 1)   // recovered after 2m
+		outage(2*time.Minute, 0) // median of 2m and 1m (halved, since the first probe succeeded)
+		outage(2*time.Minute, 0) // median of 2m, 1m and 1m
+		outage(time.Minute, 0)
+	})
+}
+
 // maybeAssertPanic is a test-table helper to assert that a function panics or not, depending on the value of wantPanic.
 func maybeAssertPanic(t *testing.T, f func(), wantPanic any) {
 	wrapped := assert.NotPanics
@@ -37,6 +37,8 @@ type concurrencyLimiter struct {
 func (cl concurrencyLimiter) ObserverForCall(ctx context.Context, state State) (Observer, error) {
 	o, err := cl.next.ObserverForCall(ctx, state)
 	if err != nil {
+		// The call is rejected (e.g. with ErrCircuitOpen), so no observer will release the slot.
+		cl.sem.Release(1)
 		return nil, err
 	}
 	return ObserverFunc(func(b bool) {
@@ -128,6 +128,29 @@ func Test_ConcurrencyLimiter(t *testing.T) {
 	}
 }
 
+func Test_ConcurrencyLimiter_releases_slot_of_rejected_call(t *testing.T) {
+	for _, block := range []bool{false, true} {
+		cb, err := hoglet.NewCircuit(
+			hoglet.NewConsecutiveFailuresBreaker(1),
+			hoglet.WithHalfOpenDelay(time.Hour),
+			hoglet.WithBreakerMiddleware(hoglet.ConcurrencyLimiter(1, block)),
+		)
+		require.NoError(t, err)
+
+		f := hoglet.Wrap(cb, func(_ context.Context, err error) (any, error) { return nil, err })
+		_, err = f(context.Background(), assert.AnError)
+		require.ErrorIs(t, err, assert.AnError)
+
+		for range 3 {
+			// a blocking limiter would wait for the slot until the timeout
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			_, err = f(ctx, nil)
+			cancel()
+			assert.ErrorIs(t, err, hoglet.ErrCircuitOpen, "rejected calls must not take up the limiter's slot (block=%v)", block)
+		}
+	}
+}
+
 func ptr[T any](in T) *T {
 	return &in
 }
@@ -18,11 +18,11 @@ func (f optionFunc) apply(o *options) error {
 }
 
 // WithHalfOpenDelay sets the duration the circuit will stay open before switching to the half-open state, where a
-// limited (~1) amount of calls are allowed that - if successful - may re-close the breaker.
+// limited (~1, see [WithHalfOpenProbes]) amount of calls are allowed that - if successful - may re-close the breaker.
 //
-// Breakers may require or constrain this value: [EWMABreaker] requires a non-zero delay (it cannot recover without one),
-// while [SlidingWindowBreaker] defaults it to its window size and rejects a value exceeding it. Such violations are
-// reported as errors by [NewCircuit].
+// Breakers may require or constrain this value: observation-based breakers like [EWMABreaker] require a non-zero delay
+// (they cannot recover without one), while [SlidingWindowBreaker] defaults it to its window size and rejects a value
+// exceeding it. Such violations are reported as errors by [NewCircuit].
 func WithHalfOpenDelay(delay time.Duration) Option {
 	return optionFunc(func(o *options) error {
 		o.halfOpenDelay = delay
@@ -30,6 +30,145 @@ func WithHalfOpenDelay(delay time.Duration) Option {
 	})
 }
 
+// WithHalfOpenProbes sets the number of probe calls admitted concurrently in the half-open state. Further calls are
+// rejected with [ErrCircuitOpen] until a probe finishes.
+// The probes are evaluated together: any failure reopens the circuit, and it only closes once n probes succeeded (unless
+// set otherwise via [WithHalfOpenSuccessThreshold]).
+//
+// Without this option, probes are admitted racily: usually one, but concurrent calls may slip through as well.
+func WithHalfOpenProbes(n uint) Option {
+	return optionFunc(func(o *options) error {
+		if n == 0 {
+			return fmt.Errorf("half-open probes must be at least 1")
+		}
+		o.halfOpenProbes = int64(n)
+		return nil
+	})
+}
+
+// WithHalfOpenSuccessThreshold sets the number of successful probe calls required to close the circuit again. The
+// circuit stays half-open until m probes succeeded, while any failed probe reopens it. This keeps a single lucky call
+// from letting full traffic onto a backend that is still unhealthy.
+//
+// Probes are limited like with [WithHalfOpenProbes], admitting 1 at a time unless set otherwise.
+func WithHalfOpenSuccessThreshold(m uint) Option {
+	return optionFunc(func(o *options) error {
+		if m == 0 {
+			return fmt.Errorf("half-open success threshold must be at least 1")
+		}
+		o.halfOpenSuccesses = int64(m)
+		return nil
+	})
+}
+
+// WithHalfOpenBackoff lets the half-open delay (see [WithHalfOpenDelay]) grow exponentially by the given factor each
+// time a half-open probe fails, up to maxDelay. Once the circuit closes, the delay starts over.
+// Each delay is shortened by a random fraction of up to jitter (between 0 and 1), so many instances of a service do not
+// probe a dead dependency in lock-step.
+//
+// The factor must be at least 1 and maxDelay at least the half-open delay, otherwise [NewCircuit] returns an error.
+// Breakers constraining the half-open delay (e.g. [SlidingWindowBreaker]) check maxDelay as well.
+func WithHalfOpenBackoff(factor float64, maxDelay time.Duration, jitter float64) Option {
+	return optionFunc(func(o *options) error {
+		if factor < 1 {
+			return fmt.Errorf("half-open backoff factor must be at least 1")
+		}
+		if jitter < 0 || jitter > 1 {
+			return fmt.Errorf("half-open backoff jitter must be between 0 and 1")
+		}
+		o.backoffFactor = factor
+		o.backoffMax = maxDelay
+		o.backoffJitter = jitter
+		return nil
+	})
+}
+
+// WithSlowStart lets the circuit ramp up traffic over the given duration after closing, instead of letting all calls
+// through at once. During the slow start, it admits a growing share of calls according to the given [Ramp] and rejects
+// the remainder with [ErrCircuitOpen], giving cold caches and freshly restarted backends time to warm up.
+// The circuit reports [StateClosed] during the slow start. It does not apply to a newly created circuit.
+func WithSlowStart(duration time.Duration, ramp Ramp) Option {
+	return optionFunc(func(o *options) error {
+		if duration < 0 {
+			return fmt.Errorf("slow start duration must not be negative")
+		}
+		if ramp != RampLinear && ramp != RampExponential {
+			return fmt.Errorf("unknown slow start ramp %d", ramp)
+		}
+		o.slowStart = duration
+		o.slowStartRamp = ramp
+		return nil
+	})
+}
+
+// WithHealthProbe lets the circuit call the given probe in the background every interval while it is open or half-open,
+// so it can recover without sacrificing a real call as the half-open probe. This is useful for rarely called
+// dependencies.
+// A successful probe is observed like a successful half-open probe call (see [WithHalfOpenProbes]) taking as long as
+// the probe, so the circuit may close unless the breaker measures durations (e.g. [SlowCallBreaker]) and the probe was
+// too slow. A failed one (see [WithFailureCondition]) leaves the circuit as it is: it does not count as a failed
+// half-open phase, so real calls may still probe the circuit once half-open. Each probe is bound to the interval via
+// its context.
+//
+// ⚠️ The probe runs in a goroutine, which has to be stopped via [Circuit.Close] once the circuit is not needed anymore.
+func WithHealthProbe(probe func(context.Context) error, interval time.Duration) Option {
+	return optionFunc(func(o *options) error {
+		if probe == nil {
+			return fmt.Errorf("health probe must not be nil")
+		}
+		if interval <= 0 {
+			return fmt.Errorf("health probe interval must be positive")
+		}
+		o.healthProbe = probe
+		o.healthProbeInterval = interval
+		return nil
+	})
+}
+
+// WithProbeEligibleByDefault sets whether calls not marked via [WithProbeEligible] may act as half-open probes. By
+// default they may; setting it to false lets only calls explicitly marked as eligible probe the circuit, while all
+// others keep getting [ErrCircuitOpen] until it closed.
+func WithProbeEligibleByDefault(eligible bool) Option {
+	return optionFunc(func(o *options) error {
+		o.probeIneligibleByDefault = !eligible
+		return nil
+	})
+}
+
+// WithAdaptiveHalfOpenDelay lets the circuit learn its half-open delay from past outages instead of using a constant
+// one: it tracks how long it took to close again after opening and uses the moving median of the recent recovery times,
+// bounded by minDelay and maxDelay. This way, a dependency with a known restart time is probed right when it is likely
+// back.
+// The half-open delay (see [WithHalfOpenDelay]) serves as the initial estimate and is required. If the first probe of
+// an outage succeeds, half of its recovery time is learned, as the dependency may have recovered any time before.
+//
+// Breakers constraining the half-open delay (e.g. [SlidingWindowBreaker]) check both the initial estimate and maxDelay.
+func WithAdaptiveHalfOpenDelay(minDelay, maxDelay time.Duration) Option {
+	return optionFunc(func(o *options) error {
+		if minDelay <= 0 || maxDelay < minDelay {
+			return fmt.Errorf("adaptive half-open delay bounds must be positive with the minimum not exceeding the maximum")
+		}
+		o.adaptiveMin = minDelay
+		o.adaptiveMax = maxDelay
+		return nil
+	})
+}
+
+// WithMinimumRequests sets the number of calls the breaker has to observe before it may open the circuit. This avoids
+// opening on the very first failures, e.g. right after startup, when a rate is not meaningful yet.
+//
+// It is honored by all built-in breakers, each counting in its own terms: time-based breakers like the
+// [SlidingWindowBreaker] count the calls within their current window, count-based breakers like the
+// [CountWindowBreaker] the calls within their window (which therefore must be at least n) and observation-based ones
+// like the [EWMABreaker] the calls observed since their creation.
+// Failed half-open probes still keep the circuit open.
+func WithMinimumRequests(n uint) Option {
+	return optionFunc(func(o *options) error {
+		o.minimumRequests = int64(n)
+		return nil
+	})
+}
+
 // WithFailureCondition allows specifying a filter function that determines whether an error should open the breaker.
 // If the provided function returns true, the error is considered a failure and the breaker may open (depending on the
 // breaker logic).
@@ -43,6 +182,22 @@ func WithFailureCondition(condition func(error) bool) Option {
 	})
 }
 
+// WithFailureWeight allows specifying a function that determines how much a failure counts, e.g. to let a
+// [context.DeadlineExceeded] count 3 times and a rate-limited call only half.
+// The provided function is only called for errors considered a failure (see [WithFailureCondition]). Successes always
+// have a weight of 1, negative weights are treated as 0.
+//
+// Weights are honored by the [EWMABreaker] and the [SlidingWindowBreaker] (and composite breakers forwarding them);
+// other breakers count every failure once.
+//
+// ⚠️ Weighting failures costs an additional allocation per call.
+func WithFailureWeight(weight func(error) float64) Option {
+	return optionFunc(func(o *options) error {
+		o.failureWeight = weight
+		return nil
+	})
+}
+
 // IgnoreContextCanceled is a helper function for [WithFailureCondition] that ignores [context.Canceled] errors.
 func IgnoreContextCanceled(err error) bool {
 	return !errors.Is(err, context.Canceled)
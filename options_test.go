@@ -18,6 +18,11 @@ func TestWithHalfOpenDelay(t *testing.T) {
 	for name, b := range map[string]hoglet.Breaker{
 		"ewma":          hoglet.NewEWMABreaker(10, 0.1),
 		"slidingWindow": hoglet.NewSlidingWindowBreaker(time.Second, 0.1),
+		"consecutive":   hoglet.NewConsecutiveFailuresBreaker(1),
+		"bucketed":      hoglet.NewBucketedSlidingWindowBreaker(time.Second, 10, 0.1),
+		"halfLife":      hoglet.NewHalfLifeEWMABreaker(time.Minute, 0.1),
+		"bayesian":      hoglet.NewBayesianBreaker(time.Second, 0.1, 0.95),
+		"failureCount":  hoglet.NewFailureCountBreaker(time.Second, 0),
 	} {
 		t.Run(name, func(t *testing.T) {
 			synctest.Test(t, func(t *testing.T) {
@@ -53,3 +58,156 @@ func TestSlidingWindowBreaker_halfOpenDelay_unset_defaults_to_window(t *testing.
 	_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1))
 	require.NoError(t, err, "unset half-open delay should default to the window size")
 }
+
+func TestWithMinimumRequests(t *testing.T) {
+	noop := func(_ context.Context, in error) (any, error) { return nil, in }
+	sentinelErr := errors.New("foo")
+
+	for name, b := range map[string]hoglet.Breaker{
+		"ewma":          hoglet.NewEWMABreaker(10, 0.1),
+		"slidingWindow": hoglet.NewSlidingWindowBreaker(time.Minute, 0.1),
+		"bucketed":      hoglet.NewBucketedSlidingWindowBreaker(time.Minute, 10, 0.1),
+		"countWindow":   hoglet.NewCountWindowBreaker(10, 0.1),
+		"consecutive":   hoglet.NewConsecutiveFailuresBreaker(1),
+		"halfLife":      hoglet.NewHalfLifeEWMABreaker(time.Minute, 0.1),
+		"bayesian":      hoglet.NewBayesianBreaker(time.Minute, 0.1, 0.95),
+		"failureCount":  hoglet.NewFailureCountBreaker(time.Minute, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			cb, err := hoglet.NewCircuit(b, hoglet.WithHalfOpenDelay(time.Second), hoglet.WithMinimumRequests(3))
+			require.NoError(t, err)
+
+			for range 2 {
+				_, err = hoglet.Wrap(cb, noop)(context.Background(), sentinelErr)
+				require.ErrorIs(t, err, sentinelErr, "expected circuit to stay closed below the minimum")
+			}
+
+			_, err = hoglet.Wrap(cb, noop)(context.Background(), sentinelErr)
+			require.ErrorIs(t, err, sentinelErr)
+
+			_, err = hoglet.Wrap(cb, noop)(context.Background(), nil)
+			assert.ErrorIs(t, err, hoglet.ErrCircuitOpen, "expected circuit to open once the minimum is reached")
+		})
+	}
+}
+
+func TestWithMinimumRequests_exceeding_count_window_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(
+		hoglet.NewCountWindowBreaker(10, 0.1),
+		hoglet.WithHalfOpenDelay(time.Second),
+		hoglet.WithMinimumRequests(11),
+	)
+	require.Error(t, err, "expected error when minimum requests exceed the window size")
+}
+
+func TestWithCloseThreshold_exceeding_threshold_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1).WithCloseThreshold(0.2))
+	require.Error(t, err, "expected error when the close threshold exceeds the threshold")
+}
+
+func TestWithCloseThreshold_per_breaker_in_composite(t *testing.T) {
+	_, err := hoglet.NewCircuit(
+		hoglet.AnyOf(
+			hoglet.NewEWMABreaker(10, 0.1).WithCloseThreshold(0.05),
+			hoglet.NewSlidingWindowBreaker(time.Minute, 0.5).WithCloseThreshold(0.2),
+		),
+		hoglet.WithHalfOpenDelay(time.Second),
+	)
+	require.NoError(t, err, "combined breakers should accept their own close thresholds")
+}
+
+func TestWithHalfOpenProbes_zero_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1), hoglet.WithHalfOpenProbes(0))
+	require.Error(t, err, "expected error when no half-open probes are admitted")
+}
+
+func TestWithHalfOpenSuccessThreshold_zero_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1), hoglet.WithHalfOpenSuccessThreshold(0))
+	require.Error(t, err, "expected error when no successes are required")
+}
+
+func TestWithHalfOpenBackoff_invalid_errors(t *testing.T) {
+	for name, opt := range map[string]hoglet.Option{
+		"factor":  hoglet.WithHalfOpenBackoff(0.5, time.Minute, 0),
+		"jitter":  hoglet.WithHalfOpenBackoff(2, time.Minute, 1.5),
+		"maximum": hoglet.WithHalfOpenBackoff(2, time.Millisecond, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1), opt)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWithSlowStart_invalid_errors(t *testing.T) {
+	for name, opt := range map[string]hoglet.Option{
+		"duration": hoglet.WithSlowStart(-time.Second, hoglet.RampLinear),
+		"ramp":     hoglet.WithSlowStart(time.Second, hoglet.Ramp(42)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1), opt)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWithHealthProbe_invalid_errors(t *testing.T) {
+	probe := func(context.Context) error { return nil }
+	for name, opt := range map[string]hoglet.Option{
+		"probe":    hoglet.WithHealthProbe(nil, time.Second),
+		"interval": hoglet.WithHealthProbe(probe, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := hoglet.NewCircuit(hoglet.NewSlidingWindowBreaker(time.Second, 0.1), opt)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWithAdaptiveHalfOpenDelay_invalid_errors(t *testing.T) {
+	for name, opts := range map[string][]hoglet.Option{
+		"minimum": {hoglet.WithHalfOpenDelay(time.Second), hoglet.WithAdaptiveHalfOpenDelay(0, time.Minute)},
+		"maximum": {hoglet.WithHalfOpenDelay(time.Second), hoglet.WithAdaptiveHalfOpenDelay(time.Minute, time.Second)},
+		"initial": {hoglet.WithAdaptiveHalfOpenDelay(time.Second, time.Minute)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			breaker := hoglet.BreakerFunc(func(bool, bool) hoglet.StateChange { return hoglet.StateChangeNone })
+			_, err := hoglet.NewCircuit(breaker, opts...)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBayesianBreaker_zero_threshold_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(hoglet.NewBayesianBreaker(time.Second, 0, 0.95))
+	require.Error(t, err, "expected error for a zero threshold")
+}
+
+func TestWithHalfOpenBackoff_maximum_exceeding_window_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(
+		hoglet.NewSlidingWindowBreaker(time.Minute, 0.1),
+		hoglet.WithHalfOpenBackoff(2, 10*time.Minute, 0),
+	)
+	require.Error(t, err, "expected error when the backed off delay may exceed the window size")
+
+	_, err = hoglet.NewCircuit(
+		hoglet.NewSlidingWindowBreaker(time.Minute, 0.1),
+		hoglet.WithHalfOpenDelay(time.Second),
+		hoglet.WithHalfOpenBackoff(2, time.Minute, 0),
+	)
+	require.NoError(t, err)
+}
+
+func TestWithAdaptiveHalfOpenDelay_maximum_exceeding_window_errors(t *testing.T) {
+	_, err := hoglet.NewCircuit(
+		hoglet.NewSlidingWindowBreaker(time.Minute, 0.1),
+		hoglet.WithAdaptiveHalfOpenDelay(time.Second, time.Hour),
+	)
+	require.Error(t, err, "expected error when the learned delay may exceed the window size")
+
+	_, err = hoglet.NewCircuit(
+		hoglet.NewSlidingWindowBreaker(time.Minute, 0.1),
+		hoglet.WithAdaptiveHalfOpenDelay(time.Second, time.Minute),
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,208 @@
+package hoglet
+
+import (
+	"math"
+)
+
+// Override is a manual override of a circuit's state by an operator (see [Circuit.ForceOpen], [Circuit.ForceClose] and
+// [Circuit.Disable]).
+type Override int
+
+const (
+	// OverrideNone means the circuit is controlled by its breaker.
+	OverrideNone Override = iota
+	// OverrideForceOpen means the circuit is held open (see [Circuit.ForceOpen]).
+	OverrideForceOpen
+	// OverrideForceClose means the circuit is pinned closed (see [Circuit.ForceClose]).
+	OverrideForceClose
+	// OverrideDisable means the circuit is bypassed entirely (see [Circuit.Disable]).
+	OverrideDisable
+)
+
+func (o Override) String() string {
+	switch o {
+	case OverrideNone:
+		return "none"
+	case OverrideForceOpen:
+		return "force-open"
+	case OverrideForceClose:
+		return "force-close"
+	case OverrideDisable:
+		return "disable"
+	default:
+		return "unknown"
+	}
+}
+
+// overrideState is the current [Override] of a circuit and the reason given for it.
+type overrideState struct {
+	override Override
+	reason   string
+}
+
+// ForceOpen holds the circuit open, e.g. during planned maintenance of the wrapped dependency: all calls are rejected
+// with [ErrCircuitOpen] and [Circuit.State] reports [StateOpen], regardless of the breaker.
+// The given reason is reported by [Circuit.Override]. The override lasts until another one is set or the circuit is
+// reset via [Circuit.Reset].
+func (c *Circuit) ForceOpen(reason string) {
+	c.override.Store(&overrideState{override: OverrideForceOpen, reason: reason})
+}
+
+// ForceClose pins the circuit closed, bypassing breaking: all calls go through and [Circuit.State] reports
+// [StateClosed]. Calls are still observed by the breaker, but it cannot open the circuit.
+// The given reason is reported by [Circuit.Override]. The override lasts until another one is set or the circuit is
+// reset via [Circuit.Reset].
+func (c *Circuit) ForceClose(reason string) {
+	c.override.Store(&overrideState{override: OverrideForceClose, reason: reason})
+}
+
+// Disable disables the circuit entirely: wrapped functions are called directly, without being observed by the breaker
+// or passing any middleware (see [WithBreakerMiddleware]), and [Circuit.State] reports [StateClosed].
+// The given reason is reported by [Circuit.Override]. The override lasts until another one is set or the circuit is
+// reset via [Circuit.Reset].
+func (c *Circuit) Disable(reason string) {
+	c.override.Store(&overrideState{override: OverrideDisable, reason: reason})
+}
+
+// Reset lifts any override and closes the circuit, resetting the statistics of its breaker as if it was newly created.
+// Calls in flight may still be observed afterwards.
+// The given reason is reported by [Circuit.Override] (along with [OverrideNone]).
+//
+// Breakers that are not built into this package (e.g. a [BreakerFunc]) keep their state.
+func (c *Circuit) Reset(reason string) {
+	c.override.Store(&overrideState{override: OverrideNone, reason: reason})
+
+	if rb, ok := c.breaker.(resetBreaker); ok {
+		rb.reset()
+	}
+
+	c.reopens.Store(0)
+	c.closedAt.Store(0) // no slow start (see [WithSlowStart]) either
+	c.openedAt.Store(0)
+}
+
+// Override returns the current [Override] of the circuit and the reason given for it. The reason of the last
+// [Circuit.Reset] is returned with [OverrideNone].
+func (c *Circuit) Override() (Override, string) {
+	o := c.override.Load()
+	if o == nil {
+		return OverrideNone, ""
+	}
+	return o.override, o.reason
+}
+
+// currentOverride returns the current [Override] of the circuit.
+func (c *Circuit) currentOverride() Override {
+	o := c.override.Load()
+	if o == nil {
+		return OverrideNone
+	}
+	return o.override
+}
+
+// resetBreaker is implemented by breakers whose statistics can be reset (see [Circuit.Reset]).
+type resetBreaker interface {
+	// reset restores the state of a newly created breaker. It may race with concurrent observations, which may get lost
+	// or be counted after resetting.
+	reset()
+}
+
+func (e *EWMABreaker) reset() {
+	e.failureRate.Store(toStore(math.SmallestNonzeroFloat64)) // see [NewEWMABreaker]
+	e.observations.Store(0)
+}
+
+func (h *HalfLifeEWMABreaker) reset() {
+	h.failureRate.Store(0)
+	h.lastObserved.Store(0)
+	h.observations.Store(0)
+}
+
+func (s *SlidingWindowBreaker) reset() {
+	s.currentStart.Store(0)
+	s.currentSuccessCount.Store(0)
+	s.currentFailureCount.Store(0)
+	s.lastSuccessCount.Store(0)
+	s.lastFailureCount.Store(0)
+	s.currentFailureCalls.Store(0)
+	s.lastFailureCalls.Store(0)
+}
+
+func (c *ConsecutiveFailuresBreaker) reset() {
+	c.streak.Store(0)
+	c.observations.Store(0)
+}
+
+func (c *CountWindowBreaker) reset() {
+	for i := range c.outcomes {
+		c.outcomes[i].Store(false)
+	}
+	c.next.Store(0)
+	c.failureCount.Store(0)
+}
+
+func (b *BucketedSlidingWindowBreaker) reset() {
+	for i := range b.buckets {
+		b.buckets[i].epoch.Store(0)
+		b.buckets[i].successCount.Store(0)
+		b.buckets[i].failureCount.Store(0)
+	}
+}
+
+func (s *SlowCallBreaker) reset() {
+	if s.window != nil {
+		s.window.reset()
+	}
+}
+
+func (l *LatencyPercentileBreaker) reset() {
+	l.currentStart.Store(0)
+	l.current.Store(newLatencySketch())
+	l.last.Store(newLatencySketch())
+}
+
+func (a *AdaptiveThrottlingBreaker) reset() {
+	a.currentStart.Store(0)
+	a.currentRequests.Store(0)
+	a.currentAccepts.Store(0)
+	a.lastRequests.Store(0)
+	a.lastAccepts.Store(0)
+}
+
+func (b *BayesianBreaker) reset() {
+	b.window.reset()
+}
+
+func (c *CUSUMBreaker) reset() {
+	c.baseline.Store(0)
+	c.sum.Store(0)
+	c.observations.Store(0)
+}
+
+func (b *BurnRateBreaker) reset() {
+	b.short.reset()
+	b.long.reset()
+}
+
+func (f *FailureCountBreaker) reset() {
+	f.window.reset()
+}
+
+// reset resets the combined breakers that can be reset.
+func (c *CompositeBreaker) reset() {
+	for i, b := range c.breakers {
+		if rb, ok := b.(resetBreaker); ok {
+			rb.reset()
+		}
+		c.open[i].Store(false)
+	}
+}
+
+// reset empties the window.
+func (w *rollingWindow) reset() {
+	w.currentStart.Store(0)
+	w.currentSuccessCount.Store(0)
+	w.currentFailureCount.Store(0)
+	w.lastSuccessCount.Store(0)
+	w.lastFailureCount.Store(0)
+}
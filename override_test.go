@@ -0,0 +1,114 @@
+package hoglet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuit_ForceOpen(t *testing.T) {
+	c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute))
+	require.NoError(t, err)
+
+	c.ForceOpen("maintenance")
+	assert.Equal(t, StateOpen, c.State())
+	override, reason := c.Override()
+	assert.Equal(t, OverrideForceOpen, override)
+	assert.Equal(t, "maintenance", reason)
+
+	_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func TestCircuit_ForceClose(t *testing.T) {
+	c, err := NewCircuit(NewEWMABreaker(10, 0.1), WithHalfOpenDelay(time.Minute))
+	require.NoError(t, err)
+
+	c.ForceClose("known flakiness")
+	for range 10 {
+		_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+		assert.ErrorIs(t, err, errSentinel, "calls should go through while pinned closed")
+	}
+	assert.Equal(t, StateClosed, c.State())
+	assert.Greater(t, c.Stats().Rate, 0.1, "the breaker should keep observing")
+
+	c.Reset("incident over")
+	assert.Equal(t, StateClosed, c.State())
+	assert.Zero(t, c.Stats().Rate, "resetting should reset the breaker")
+	override, reason := c.Override()
+	assert.Equal(t, OverrideNone, override)
+	assert.Equal(t, "incident over", reason)
+
+	_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+	assert.ErrorIs(t, err, errSentinel)
+	assert.Equal(t, StateOpen, c.State(), "the breaker should open the circuit again after resetting")
+}
+
+func TestCircuit_Disable(t *testing.T) {
+	var observed bool
+	breaker := BreakerFunc(func(bool, bool) StateChange {
+		observed = true
+		return StateChangeOpen
+	})
+
+	c, err := NewCircuit(breaker)
+	require.NoError(t, err)
+
+	c.Disable("debugging")
+	_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+	assert.ErrorIs(t, err, errSentinel)
+	assert.False(t, observed, "calls must not be observed while disabled")
+	assert.Equal(t, StateClosed, c.State())
+}
+
+func TestCircuit_Reset_closes_open_circuit(t *testing.T) {
+	c, err := NewCircuit(&mockBreaker{}, WithHalfOpenDelay(time.Minute))
+	require.NoError(t, err)
+
+	_, err = Wrap(c, noop)(context.Background(), noopInFailure)
+	require.ErrorIs(t, err, errSentinel)
+	require.Equal(t, StateOpen, c.State())
+
+	c.Reset("dependency fixed")
+	assert.Equal(t, StateClosed, c.State())
+	_, err = Wrap(c, noop)(context.Background(), noopInSuccess)
+	assert.NoError(t, err)
+}
+
+func TestBreakers_reset(t *testing.T) {
+	for name, newBreaker := range map[string]func() Breaker{
+		"ewma":         func() Breaker { return NewEWMABreaker(10, 0.5) },
+		"halfLife":     func() Breaker { return NewHalfLifeEWMABreaker(time.Minute, 0.5) },
+		"sliding":      func() Breaker { return NewSlidingWindowBreaker(time.Minute, 0.5) },
+		"consecutive":  func() Breaker { return NewConsecutiveFailuresBreaker(100) },
+		"countWindow":  func() Breaker { return NewCountWindowBreaker(10, 0.5) },
+		"bucketed":     func() Breaker { return NewBucketedSlidingWindowBreaker(time.Minute, 6, 0.5) },
+		"slowCall":     func() Breaker { return NewSlowCallBreaker(time.Minute, time.Second, 0.5) },
+		"bayesian":     func() Breaker { return NewBayesianBreaker(time.Minute, 0.5, 0.95) },
+		"cusum":        func() Breaker { return NewCUSUMBreaker(10, 0.01, 100) },
+		"failureCount": func() Breaker { return NewFailureCountBreaker(time.Minute, 100) },
+		"composite": func() Breaker {
+			return AnyOf(NewSlidingWindowBreaker(time.Minute, 0.5), NewConsecutiveFailuresBreaker(100))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fresh, err := NewCircuit(newBreaker(), WithHalfOpenDelay(time.Second))
+			require.NoError(t, err)
+			c, err := NewCircuit(newBreaker(), WithHalfOpenDelay(time.Second))
+			require.NoError(t, err)
+
+			for i := range 10 {
+				_, _ = Wrap(c, noop)(context.Background(), noopIn(i%2))
+			}
+			require.NotEqual(t, fresh.Stats(), c.Stats())
+
+			c.Reset("test")
+			want, got := fresh.Stats(), c.Stats()
+			want.WindowStart, got.WindowStart = time.Time{}, time.Time{} // bucketed windows start relative to now
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package hoglet
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// recoverySamples is the number of past recovery times the adaptive half-open delay is learned from (see
+// [WithAdaptiveHalfOpenDelay]). It is odd, so the median is a sample itself once all slots are filled.
+const recoverySamples = 9
+
+// recoveryTimes keeps the durations of the most recent outages of a circuit, from opening to closing again, and
+// estimates the next one via their moving median.
+//
+// It is locked, since it is only updated when a circuit closes; the estimate is published via the circuit's atomic
+// learnedDelay instead of being read from here on the hot path.
+type recoveryTimes struct {
+	mu      sync.Mutex
+	samples [recoverySamples]time.Duration
+	next    int
+	count   int
+}
+
+// add records the given recovery time and returns the median of the recorded ones.
+func (r *recoveryTimes) add(d time.Duration) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % len(r.samples)
+	r.count = min(r.count+1, len(r.samples))
+
+	sorted := r.samples
+	slices.Sort(sorted[:r.count])
+	return sorted[r.count/2]
+}
@@ -0,0 +1,22 @@
+package hoglet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryTimes_add_returns_moving_median(t *testing.T) {
+	r := &recoveryTimes{}
+
+	assert.Equal(t, 3*time.Second, r.add(3*time.Second))
+	assert.Equal(t, 3*time.Second, r.add(time.Second))
+	assert.Equal(t, 2*time.Second, r.add(2*time.Second))
+
+	// older samples are evicted once all slots are filled
+	for range recoverySamples {
+		r.add(time.Minute)
+	}
+	assert.Equal(t, time.Minute, r.add(time.Second))
+}
@@ -0,0 +1,98 @@
+package hoglet
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// sketchRelativeAccuracy is the relative accuracy of the quantiles estimated by a [latencySketch].
+const sketchRelativeAccuracy = 0.02
+
+var (
+	// sketchGamma is the ratio between the upper and lower bound of each bucket of a [latencySketch].
+	sketchGamma = (1 + sketchRelativeAccuracy) / (1 - sketchRelativeAccuracy)
+	// sketchLogGamma is cached to avoid recomputing it for every observation.
+	sketchLogGamma = math.Log(sketchGamma)
+	// sketchBuckets is the number of buckets needed to track durations of up to one hour with the given accuracy.
+	// Longer durations are clamped into the last bucket.
+	sketchBuckets = sketchIndex(time.Hour) + 1
+)
+
+// latencySketch is a lock-free streaming quantile sketch for durations, following the approach of DDSketch
+// (https://arxiv.org/abs/1908.10693): durations are counted in logarithmically sized buckets, which bounds the
+// relative error of any quantile estimate by [sketchRelativeAccuracy].
+//
+// A zero latencySketch is not usable. Initialize with [newLatencySketch].
+type latencySketch struct {
+	counts   []atomic.Int64
+	count    atomic.Int64
+	maxIndex atomic.Int64 // highest bucket index observed; allows scanning for high quantiles from the top
+}
+
+func newLatencySketch() *latencySketch {
+	return &latencySketch{
+		counts: make([]atomic.Int64, sketchBuckets),
+	}
+}
+
+// sketchIndex returns the bucket index for the given duration.
+func sketchIndex(d time.Duration) int {
+	if d <= time.Nanosecond {
+		return 0
+	}
+	return int(math.Ceil(math.Log(float64(d)) / sketchLogGamma))
+}
+
+// sketchValue returns the representative duration for the given bucket index, which lies within the relative accuracy
+// of every duration counted in it.
+func sketchValue(index int) time.Duration {
+	return time.Duration(2 * math.Pow(sketchGamma, float64(index)) / (sketchGamma + 1))
+}
+
+// add counts the given duration.
+func (s *latencySketch) add(d time.Duration) {
+	index := min(sketchIndex(d), len(s.counts)-1)
+	s.counts[index].Add(1)
+	s.count.Add(1)
+
+	for {
+		maxIndex := s.maxIndex.Load()
+		if int64(index) <= maxIndex || s.maxIndex.CompareAndSwap(maxIndex, int64(index)) {
+			return
+		}
+	}
+}
+
+// reset clears all counts. Concurrent calls to add may partially survive a reset.
+func (s *latencySketch) reset() {
+	s.count.Store(0)
+	s.maxIndex.Store(0)
+	for i := range s.counts {
+		s.counts[i].Store(0)
+	}
+}
+
+// weightedQuantile estimates the quantile q (0.0-1.0) over the union of both sketches, with each count of other being
+// weighted by otherWeight. It returns 0 if no durations have been counted.
+//
+// The buckets are scanned from the top, which keeps the scan short for the high quantiles usually of interest.
+func (s *latencySketch) weightedQuantile(q float64, other *latencySketch, otherWeight float64) time.Duration {
+	total := float64(s.count.Load()) + float64(other.count.Load())*otherWeight
+	if total == 0 {
+		return 0
+	}
+
+	// the number of counts allowed above the quantile
+	rank := (1 - q) * total
+
+	var seen float64
+	for i := int(max(s.maxIndex.Load(), other.maxIndex.Load())); i > 0; i-- {
+		seen += float64(s.counts[i].Load()) + float64(other.counts[i].Load())*otherWeight
+		if seen > rank {
+			return sketchValue(i)
+		}
+	}
+
+	return sketchValue(0)
+}
@@ -0,0 +1,39 @@
+package hoglet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencySketch_weightedQuantile(t *testing.T) {
+	s := newLatencySketch()
+	empty := newLatencySketch()
+
+	for i := 1; i <= 1000; i++ {
+		s.add(time.Duration(i) * time.Millisecond)
+	}
+
+	for _, q := range []float64{0.5, 0.95, 0.99} {
+		want := time.Duration(q * float64(1000*time.Millisecond))
+		assert.InEpsilon(t, want, s.weightedQuantile(q, empty, 1), 2*sketchRelativeAccuracy, "quantile %v", q)
+	}
+}
+
+func TestLatencySketch_weightedQuantile_weights_other(t *testing.T) {
+	s := newLatencySketch()
+	other := newLatencySketch()
+
+	for range 100 {
+		s.add(time.Millisecond)
+		other.add(time.Second)
+	}
+
+	assert.InEpsilon(t, time.Second, s.weightedQuantile(0.9, other, 1), 2*sketchRelativeAccuracy)
+	assert.InEpsilon(t, time.Millisecond, s.weightedQuantile(0.9, other, 0), 2*sketchRelativeAccuracy)
+}
+
+func TestLatencySketch_empty(t *testing.T) {
+	assert.Zero(t, newLatencySketch().weightedQuantile(0.99, newLatencySketch(), 1))
+}
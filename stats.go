@@ -0,0 +1,309 @@
+package hoglet
+
+import (
+	"math"
+	"time"
+)
+
+// BreakerStats is a snapshot of a breaker's statistics, e.g. for dashboards, debugging or tests. See the Stats method
+// of each breaker for how it fills the fields; fields that do not apply to a breaker are left zero.
+type BreakerStats struct {
+	// Rate is the value the breaker compares to its threshold, usually the failure rate (0.0-1.0).
+	Rate float64
+	// Threshold is the value of Rate above which the breaker opens.
+	Threshold float64
+
+	// Successes is the number of successes the rate is currently based on.
+	Successes float64
+	// Failures is the number of failures the rate is currently based on. It may be fractional for weighted failures
+	// (see [WithFailureWeight]) or partially counted windows.
+	Failures float64
+
+	// WindowStart is the start of the current window of time-based breakers.
+	WindowStart time.Time
+
+	// Breakers holds the statistics of the breakers combined by a [CompositeBreaker].
+	Breakers []BreakerStats
+}
+
+// statsBreaker is implemented by breakers providing statistics.
+type statsBreaker interface {
+	Stats() BreakerStats
+}
+
+// Stats returns a snapshot of the statistics of the circuit's breaker. It returns zero stats if the breaker does not
+// provide statistics (e.g. a [BreakerFunc] or a nil breaker).
+//
+// Like [Circuit.State], it should only be used for informational purposes.
+func (c *Circuit) Stats() BreakerStats {
+	if sb, ok := c.breaker.(statsBreaker); ok {
+		return sb.Stats()
+	}
+	return BreakerStats{}
+}
+
+// timeFromNanos converts a monotonic-nanos timestamp (as produced by [nowNanos]) to a [time.Time]. A zero timestamp
+// yields the zero time.
+func timeFromNanos(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return start.Add(time.Duration(nanos))
+}
+
+// lastWindowShare returns the portion of the last window still "visible" in the current one, the given time after the
+// current window started. Breakers rotating two windows weight the last window's counts by it.
+func lastWindowShare(size, sinceStart time.Duration) float64 {
+	return max(0, size.Seconds()-sinceStart.Seconds()) / size.Seconds()
+}
+
+// windowWeights returns the weights of the last and the current window's counts of a [rollingWindow], the given time
+// after the current window started. Since windows are only rotated upon observing, it accounts for rotations that would
+// have happened by now.
+//
+// Breakers restarting their current window upon observing (like the [SlidingWindowBreaker]) rather than rotating
+// aligned keep the current window in full however long ago it passed, so their counts are weighted by
+// [lastWindowShare] alone.
+func windowWeights(size, sinceStart time.Duration) (last, current float64) {
+	switch {
+	case sinceStart <= size:
+		return lastWindowShare(size, sinceStart), 1
+	case sinceStart <= 2*size:
+		return 0, (2*size.Seconds() - sinceStart.Seconds()) / size.Seconds()
+	default:
+		return 0, 0
+	}
+}
+
+// rate returns failures/total, or 0 if there are no observations.
+func rate(failures, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return failures / total
+}
+
+// snapshot returns the weighted failure and total counts over the window at the current time, without counting an
+// observation.
+func (w *rollingWindow) snapshot() (failures, total float64, windowStart int64) {
+	windowStart = w.currentStart.Load()
+	lastWeight, currentWeight := windowWeights(w.size, sinceNanos(windowStart))
+
+	lastFailures := float64(w.lastFailureCount.Load())
+	currentFailures := float64(w.currentFailureCount.Load())
+
+	failures = lastFailures*lastWeight + currentFailures*currentWeight
+	total = (lastFailures+float64(w.lastSuccessCount.Load()))*lastWeight +
+		(currentFailures+float64(w.currentSuccessCount.Load()))*currentWeight
+
+	return failures, total, windowStart
+}
+
+// Stats returns a snapshot of the breaker's statistics: the failure rate and its threshold. The [EWMABreaker] keeps no
+// counts.
+func (e *EWMABreaker) Stats() BreakerStats {
+	failureRate := fromStore(e.failureRate.Load())
+	if failureRate == math.SmallestNonzeroFloat64 {
+		failureRate = 0 // no observation yet
+	}
+
+	return BreakerStats{
+		Rate:      failureRate,
+		Threshold: e.threshold,
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the failure rate as of the last observation and its threshold.
+// The [HalfLifeEWMABreaker] keeps no counts.
+func (h *HalfLifeEWMABreaker) Stats() BreakerStats {
+	return BreakerStats{
+		Rate:      fromStore(h.failureRate.Load()),
+		Threshold: h.threshold,
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the failure rate, its threshold, the counts within the window
+// (with the last window counting proportionally) and the start of the current window. Like the breaker, it keeps
+// counting the current window in full once it passed, until the next observation starts a new one.
+func (s *SlidingWindowBreaker) Stats() BreakerStats {
+	windowStart := s.currentStart.Load()
+	lastWeight := lastWindowShare(s.windowSize, sinceNanos(windowStart))
+
+	failures := fromStore(s.lastFailureCount.Load())*lastWeight + fromStore(s.currentFailureCount.Load())
+	successes := float64(s.lastSuccessCount.Load())*lastWeight + float64(s.currentSuccessCount.Load())
+
+	return BreakerStats{
+		Rate:        rate(failures, failures+successes),
+		Threshold:   s.threshold,
+		Successes:   successes,
+		Failures:    failures,
+		WindowStart: timeFromNanos(windowStart),
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the current streak of failures and the number of failures
+// above which it opens. Both are also reported as Rate and Threshold.
+func (c *ConsecutiveFailuresBreaker) Stats() BreakerStats {
+	streak := float64(c.streak.Load())
+
+	return BreakerStats{
+		Rate:      streak,
+		Threshold: float64(c.threshold) - 1,
+		Failures:  streak,
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the failure rate, its threshold and the counts within the
+// window.
+func (c *CountWindowBreaker) Stats() BreakerStats {
+	failures := float64(c.failureCount.Load())
+	total := float64(min(c.next.Load(), uint64(len(c.outcomes))))
+
+	return BreakerStats{
+		Rate:      rate(failures, total),
+		Threshold: c.threshold,
+		Successes: total - failures,
+		Failures:  failures,
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the failure rate, its threshold, the counts within the window
+// and the start of its oldest bucket.
+func (b *BucketedSlidingWindowBreaker) Stats() BreakerStats {
+	if b.bucketWidth <= 0 {
+		return BreakerStats{Threshold: b.threshold}
+	}
+
+	epoch := nowNanos() / b.bucketWidth
+
+	var failures, successes float64
+	for i := range b.buckets {
+		// see [BucketedSlidingWindowBreaker.observe]
+		if epoch-b.buckets[i].epoch.Load() >= int64(len(b.buckets)) {
+			continue
+		}
+		failures += float64(b.buckets[i].failureCount.Load())
+		successes += float64(b.buckets[i].successCount.Load())
+	}
+
+	return BreakerStats{
+		Rate:        rate(failures, failures+successes),
+		Threshold:   b.threshold,
+		Successes:   successes,
+		Failures:    failures,
+		WindowStart: timeFromNanos((epoch - int64(len(b.buckets)) + 1) * b.bucketWidth),
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the slow call rate, its threshold, the counts within the window
+// (slow calls are reported as failures) and the start of the current window.
+func (s *SlowCallBreaker) Stats() BreakerStats {
+	if s.window == nil {
+		return BreakerStats{}
+	}
+	return s.window.Stats()
+}
+
+// Stats returns a snapshot of the breaker's statistics: the estimated percentile and the limit (both in seconds), the
+// number of calls within the window (reported as successes) and the start of the current window. See also
+// [LatencyPercentileBreaker.Percentile].
+func (l *LatencyPercentileBreaker) Stats() BreakerStats {
+	if l.current.Load() == nil {
+		return BreakerStats{}
+	}
+
+	current, last, lastWindowWeight := l.sketches()
+
+	return BreakerStats{
+		Rate:        l.Percentile().Seconds(),
+		Threshold:   l.limit.Seconds(),
+		Successes:   float64(current.count.Load()) + float64(last.count.Load())*lastWindowWeight,
+		WindowStart: timeFromNanos(l.currentStart.Load()),
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the current rejection probability, the accepted requests
+// (reported as successes) and the remaining requests (reported as failures) within the window, and the start of the
+// current window. There is no threshold. The window is weighted like by the [SlidingWindowBreaker] (see
+// [SlidingWindowBreaker.Stats]).
+func (a *AdaptiveThrottlingBreaker) Stats() BreakerStats {
+	windowStart := a.currentStart.Load()
+	lastWeight := lastWindowShare(a.windowSize, sinceNanos(windowStart))
+
+	requests := float64(a.lastRequests.Load())*lastWeight + float64(a.currentRequests.Load())
+	accepts := float64(a.lastAccepts.Load())*lastWeight + float64(a.currentAccepts.Load())
+
+	return BreakerStats{
+		Rate:        max(0, (requests-a.k*accepts)/(requests+1)),
+		Successes:   accepts,
+		Failures:    requests - accepts,
+		WindowStart: timeFromNanos(windowStart),
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the observed failure rate (not its credible interval), its
+// threshold, the counts within the window and the start of the current window.
+func (b *BayesianBreaker) Stats() BreakerStats {
+	failures, total, windowStart := b.window.snapshot()
+
+	return BreakerStats{
+		Rate:        rate(failures, total),
+		Threshold:   b.threshold,
+		Successes:   total - failures,
+		Failures:    failures,
+		WindowStart: timeFromNanos(windowStart),
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the cumulative sum and its limit. See also
+// [CUSUMBreaker.Baseline].
+func (c *CUSUMBreaker) Stats() BreakerStats {
+	return BreakerStats{
+		Rate:      c.CumulativeSum(),
+		Threshold: c.limit,
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics for its short window: the burn rate, its factor, the counts
+// within the window and the start of the current window.
+func (b *BurnRateBreaker) Stats() BreakerStats {
+	failures, total, windowStart := b.short.snapshot()
+
+	return BreakerStats{
+		Rate:        rate(failures, total) / b.errorBudget,
+		Threshold:   b.shortFactor,
+		Successes:   total - failures,
+		Failures:    failures,
+		WindowStart: timeFromNanos(windowStart),
+	}
+}
+
+// Stats returns a snapshot of the breaker's statistics: the number of failures within the window and the maximum
+// number of failures (also reported as Rate and Threshold), the counts within the window and the start of the current
+// window.
+func (f *FailureCountBreaker) Stats() BreakerStats {
+	failures, total, windowStart := f.window.snapshot()
+
+	return BreakerStats{
+		Rate:        failures,
+		Threshold:   f.maxFailures,
+		Successes:   total - failures,
+		Failures:    failures,
+		WindowStart: timeFromNanos(windowStart),
+	}
+}
+
+// Stats returns the statistics of the combined breakers in Breakers. Breakers not providing statistics (e.g. a
+// [BreakerFunc]) are reported as zero stats.
+func (c *CompositeBreaker) Stats() BreakerStats {
+	stats := make([]BreakerStats, len(c.breakers))
+	for i, b := range c.breakers {
+		if sb, ok := b.(statsBreaker); ok {
+			stats[i] = sb.Stats()
+		}
+	}
+
+	return BreakerStats{
+		Breakers: stats,
+	}
+}
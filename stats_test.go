@@ -0,0 +1,84 @@
+package hoglet
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuit_Stats(t *testing.T) {
+	c, err := NewCircuit(NewSlidingWindowBreaker(time.Minute, 0.6))
+	require.NoError(t, err)
+
+	_, err = Wrap(c, noop)(t.Context(), noopInSuccess)
+	require.NoError(t, err)
+	_, err = Wrap(c, noop)(t.Context(), noopInFailure)
+	require.ErrorIs(t, err, errSentinel)
+
+	stats := c.Stats()
+	assert.Equal(t, 0.5, stats.Rate)
+	assert.Equal(t, 0.6, stats.Threshold)
+	assert.InDelta(t, 1, stats.Successes, 0.01)
+	assert.InDelta(t, 1, stats.Failures, 0.01)
+	assert.WithinDuration(t, time.Now(), stats.WindowStart, time.Second)
+}
+
+func TestSlidingWindowBreaker_Stats_after_idle_windows(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		b := NewSlidingWindowBreaker(time.Minute, 0.6)
+		b.observe(false, false)
+		b.observe(false, true)
+
+		// The breaker keeps the passed window until the next observation, however long ago it passed.
+		time.Sleep(3 * time.Minute)
+		stats := b.Stats()
+		assert.Equal(t, 0.5, stats.Rate)
+		assert.InDelta(t, 1, stats.Successes, 0.01)
+		assert.InDelta(t, 1, stats.Failures, 0.01)
+	})
+}
+
+func TestCircuit_Stats_without_stats_breaker(t *testing.T) {
+	c, err := NewCircuit(nil)
+	require.NoError(t, err)
+	assert.Zero(t, c.Stats())
+}
+
+func TestEWMABreaker_Stats(t *testing.T) {
+	b := NewEWMABreaker(10, 0.5)
+	assert.Zero(t, b.Stats().Rate, "expected no failure rate before the first observation")
+
+	b.observe(false, true)
+	assert.Equal(t, BreakerStats{Rate: 1, Threshold: 0.5}, b.Stats())
+}
+
+func TestCompositeBreaker_Stats(t *testing.T) {
+	b := AnyOf(NewConsecutiveFailuresBreaker(3), BreakerFunc(func(bool, bool) StateChange { return StateChangeNone }))
+	b.observe(false, true)
+
+	stats := b.Stats()
+	require.Len(t, stats.Breakers, 2)
+	assert.Equal(t, 1.0, stats.Breakers[0].Failures)
+	assert.Zero(t, stats.Breakers[1])
+}
+
+func TestWindowWeights(t *testing.T) {
+	tests := []struct {
+		sinceStart        time.Duration
+		wantLast, wantCur float64
+	}{
+		{sinceStart: 0, wantLast: 1, wantCur: 1},
+		{sinceStart: 15 * time.Second, wantLast: 0.75, wantCur: 1},
+		{sinceStart: 90 * time.Second, wantLast: 0, wantCur: 0.5},
+		{sinceStart: 3 * time.Minute, wantLast: 0, wantCur: 0},
+	}
+
+	for _, tt := range tests {
+		last, current := windowWeights(time.Minute, tt.sinceStart)
+		assert.InDelta(t, tt.wantLast, last, 1e-9, "last weight after %s", tt.sinceStart)
+		assert.InDelta(t, tt.wantCur, current, 1e-9, "current weight after %s", tt.sinceStart)
+	}
+}
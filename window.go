@@ -0,0 +1,80 @@
+package hoglet
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// rollingWindow counts successes and failures over a sliding time window, the same way the [SlidingWindowBreaker]
+// does: it keeps a current and a last window and weights the latter by the portion still visible in the current one.
+//
+// A zero rollingWindow is not usable; its size must be set.
+type rollingWindow struct {
+	size time.Duration
+
+	// State
+
+	currentStart        atomic.Int64 // monotonic nanoseconds since start (see nowNanos)
+	currentSuccessCount atomic.Int64
+	currentFailureCount atomic.Int64
+	lastSuccessCount    atomic.Int64
+	lastFailureCount    atomic.Int64
+}
+
+// add counts the given outcome and returns the weighted failure and total counts over the window, including it.
+func (w *rollingWindow) add(failure bool) (failures, total float64) {
+	var (
+		lastFailureCount    int64
+		lastSuccessCount    int64
+		currentFailureCount int64
+		currentSuccessCount int64
+	)
+
+	currentStartNanos := w.currentStart.Load()
+	sinceStart := sinceNanos(currentStartNanos)
+
+	if currentStartNanos == 0 || sinceStart > w.size {
+		// Rotate the windows the same way [windowWeights] accounts for rotations: the new window starts where the current
+		// one ended, unless that is more than a window ago too, in which case the current window is stale and dropped.
+		// As in the [SlidingWindowBreaker], the CompareAndSwap ensures only one goroutine rotates.
+		stale := currentStartNanos == 0 || sinceStart > 2*w.size
+		newStart := currentStartNanos + int64(w.size)
+		if stale {
+			newStart = nowNanos()
+		}
+
+		if w.currentStart.CompareAndSwap(currentStartNanos, newStart) {
+			lastFailureCount = w.currentFailureCount.Swap(0)
+			lastSuccessCount = w.currentSuccessCount.Swap(0)
+			if stale {
+				lastFailureCount, lastSuccessCount = 0, 0
+			}
+			w.lastFailureCount.Store(lastFailureCount)
+			w.lastSuccessCount.Store(lastSuccessCount)
+			sinceStart = sinceNanos(newStart)
+		} else {
+			// another goroutine rotated the windows already
+			lastFailureCount = w.lastFailureCount.Load()
+			lastSuccessCount = w.lastSuccessCount.Load()
+			sinceStart = sinceNanos(w.currentStart.Load())
+		}
+	} else {
+		lastFailureCount = w.lastFailureCount.Load()
+		lastSuccessCount = w.lastSuccessCount.Load()
+	}
+
+	if failure {
+		currentFailureCount = w.currentFailureCount.Add(1)
+		currentSuccessCount = w.currentSuccessCount.Load()
+	} else {
+		currentSuccessCount = w.currentSuccessCount.Add(1)
+		currentFailureCount = w.currentFailureCount.Load()
+	}
+
+	lastWindowWeight := lastWindowShare(w.size, sinceStart)
+
+	failures = float64(lastFailureCount)*lastWindowWeight + float64(currentFailureCount)
+	total = float64(lastFailureCount+lastSuccessCount)*lastWindowWeight + float64(currentFailureCount+currentSuccessCount)
+
+	return failures, total
+}
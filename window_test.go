@@ -0,0 +1,63 @@
+package hoglet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollingWindow_add(t *testing.T) {
+	w := &rollingWindow{size: time.Minute}
+
+	w.add(false)
+	failures, total := w.add(true)
+	assert.Equal(t, 1.0, failures)
+	assert.Equal(t, 2.0, total)
+}
+
+func TestRollingWindow_weights_last_window(t *testing.T) {
+	w := &rollingWindow{size: time.Minute}
+
+	w.add(true)
+	w.add(true)
+
+	// simulate passage of time: rotate, then pretend half of the new window has passed
+	w.currentStart.Store(nowNanos() - int64(w.size+time.Second))
+	w.add(false)
+	w.currentStart.Store(nowNanos() - int64(w.size/2))
+
+	failures, total := w.add(false)
+	assert.InDelta(t, 1.0, failures, 0.01, "the last window's failures should count half")
+	assert.InDelta(t, 3.0, total, 0.01)
+}
+
+func TestRollingWindow_rotates_current_counts(t *testing.T) {
+	w := &rollingWindow{size: time.Minute}
+
+	w.add(true)
+	w.add(true)
+
+	// simulate passage of time: the current window ended just now
+	w.currentStart.Store(nowNanos() - int64(w.size))
+	failures, total := w.add(false)
+	assert.InDelta(t, 2.0, failures, 0.01, "the rotated failures should count fully")
+	assert.InDelta(t, 3.0, total, 0.01)
+
+	lastWeight, _ := windowWeights(w.size, sinceNanos(w.currentStart.Load()))
+	assert.InDelta(t, 1.0, lastWeight, 0.01, "the new window should start where the last one ended")
+}
+
+func TestRollingWindow_drops_stale_window(t *testing.T) {
+	w := &rollingWindow{size: 10 * time.Second}
+
+	for range 5 {
+		w.add(true)
+	}
+
+	// simulate passage of time: idle for an hour
+	w.currentStart.Store(nowNanos() - int64(time.Hour))
+	failures, total := w.add(false)
+	assert.Zero(t, failures, "failures from an hour ago must not count")
+	assert.Equal(t, 1.0, total)
+}